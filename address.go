@@ -13,5 +13,8 @@ type Address struct {
 		PhoneNumber string `json:"phone_number"`
 		PostalCode  string `json:"postal_code"`
 		State       string `json:"state"`
+		// API v2 properties
+		CreatedAt NullTime `json:"created_at"`
+		UpdatedAt NullTime `json:"updated_at"`
 	} `json:"attributes"`
 }
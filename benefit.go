@@ -0,0 +1,31 @@
+package patreon
+
+import "encoding/json"
+
+// Benefit represents a benefit added to the campaign, which can be added to a tier (API v2).
+// Valid relationships: tiers, deliverables, campaign, campaign_installation.
+type Benefit struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		AppExternalID                 string          `json:"app_external_id"`
+		AppMeta                       json.RawMessage `json:"app_meta"`
+		BenefitType                   string          `json:"benefit_type"`
+		CreatedAt                     NullTime        `json:"created_at"`
+		DeliverablesDueTodayCount     int             `json:"deliverables_due_today_count"`
+		DeliveredDeliverablesCount    int             `json:"delivered_deliverables_count"`
+		Description                   string          `json:"description"`
+		IsDeleted                     bool            `json:"is_deleted"`
+		IsEnded                       bool            `json:"is_ended"`
+		IsPublished                   bool            `json:"is_published"`
+		NextDeliverableDueDate        NullTime        `json:"next_deliverable_due_date"`
+		NotDeliveredDeliverablesCount int             `json:"not_delivered_deliverables_count"`
+		RuleType                      string          `json:"rule_type"`
+		TiersCount                    int             `json:"tiers_count"`
+		Title                         string          `json:"title"`
+	} `json:"attributes"`
+	Relationships struct {
+		Campaign *CampaignRelationship `json:"campaign,omitempty"`
+		Tiers    *TiersRelationship    `json:"tiers,omitempty"`
+	} `json:"relationships"`
+}
@@ -1,10 +1,16 @@
 package patreon
 
-// CampaignDefaultRelations specifies default includes for Campaign.
-const CampaignDefaultRelations = "rewards,creator,goals"
+const (
+	// CampaignDefaultRelations specifies default includes for Campaign.
+	CampaignDefaultRelations = "rewards,creator,goals"
+
+	// CampaignV2DefaultRelations specifies default includes for Campaign fetched with API v2.
+	CampaignV2DefaultRelations = "tiers,creator,benefits,goals"
+)
 
 // Campaign represents Patreon's campaign.
 // Valid relationships: rewards, creator, goals, pledges, current_user_pledge, post_aggregation, categories, preview_token.
+// API v2 relationships: tiers, creator, benefits, goals.
 type Campaign struct {
 	Type       string `json:"type"`
 	ID         string `json:"id"`
@@ -32,6 +38,16 @@ type Campaign struct {
 		PatronCount                   int      `json:"patron_count"`
 		CreationCount                 int      `json:"creation_count"`
 		OutstandingPaymentAmountCents int      `json:"outstanding_payment_amount_cents"`
+		// API v2 properties
+		DiscordServerID   string `json:"discord_server_id"`
+		GoogleAnalyticsID string `json:"google_analytics_id"`
+		HasRSS            bool   `json:"has_rss"`
+		HasSentRSSNotify  bool   `json:"has_sent_rss_notify"`
+		RSSArtworkURL     string `json:"rss_artwork_url"`
+		RSSFeedTitle      string `json:"rss_feed_title"`
+		ShowEarnings      bool   `json:"show_earnings"`
+		URL               string `json:"url"`
+		Vanity            string `json:"vanity"`
 	} `json:"attributes"`
 	Relationships struct {
 		Categories      *CategoriesRelationship      `json:"categories,omitempty"`
@@ -40,6 +56,8 @@ type Campaign struct {
 		Goals           *GoalsRelationship           `json:"goals,omitempty"`
 		Pledges         *PledgesRelationship         `json:"pledges,omitempty"`
 		PostAggregation *PostAggregationRelationship `json:"post_aggregation,omitempty"`
+		Tiers           *TiersRelationship           `json:"tiers,omitempty"`
+		Benefits        *BenefitsRelationship        `json:"benefits,omitempty"`
 	} `json:"relationships"`
 }
 
@@ -47,4 +65,95 @@ type Campaign struct {
 type CampaignResponse struct {
 	Data     []Campaign `json:"data"`
 	Included Includes   `json:"included"`
+	// API v2 pagination
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Meta struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"meta"`
+
+	documentSource
+}
+
+// CampaignByIDResponse wraps Patreon's fetch campaign by ID API response
+type CampaignByIDResponse struct {
+	Data     Campaign `json:"data"`
+	Included Includes `json:"included"`
+	Links    struct {
+		Self string `json:"self"`
+	} `json:"links"`
+
+	documentSource
+}
+
+// RewardsOf returns the included rewards of the campaign.
+// It returns ErrNotIncluded if any of the rewards is missing from includes.
+func (r *CampaignResponse) RewardsOf(c *Campaign) ([]*Reward, error) {
+	if c.Relationships.Rewards == nil {
+		return nil, nil
+	}
+
+	rewards := make([]*Reward, 0, len(c.Relationships.Rewards.Data))
+	for _, data := range c.Relationships.Rewards.Data {
+		item, err := r.Included.resolve(data)
+		if err != nil {
+			return nil, err
+		}
+
+		reward, ok := item.(*Reward)
+		if !ok {
+			return nil, unexpectedType(data, item)
+		}
+
+		rewards = append(rewards, reward)
+	}
+
+	return rewards, nil
+}
+
+// GoalsOf returns the included goals of the campaign.
+// It returns ErrNotIncluded if any of the goals is missing from includes.
+func (r *CampaignResponse) GoalsOf(c *Campaign) ([]*Goal, error) {
+	if c.Relationships.Goals == nil {
+		return nil, nil
+	}
+
+	goals := make([]*Goal, 0, len(c.Relationships.Goals.Data))
+	for _, data := range c.Relationships.Goals.Data {
+		item, err := r.Included.resolve(data)
+		if err != nil {
+			return nil, err
+		}
+
+		goal, ok := item.(*Goal)
+		if !ok {
+			return nil, unexpectedType(data, item)
+		}
+
+		goals = append(goals, goal)
+	}
+
+	return goals, nil
+}
+
+// CreatorOf returns the included user who created the campaign.
+// It returns nil if the campaign has no creator relationship and ErrNotIncluded if the creator is missing from includes.
+func (r *CampaignResponse) CreatorOf(c *Campaign) (*User, error) {
+	if c.Relationships.Creator == nil || c.Relationships.Creator.Data.ID == "" {
+		return nil, nil
+	}
+
+	data := c.Relationships.Creator.Data
+	item, err := r.Included.resolve(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := item.(*User)
+	if !ok {
+		return nil, unexpectedType(data, item)
+	}
+
+	return user, nil
 }
@@ -1,6 +1,8 @@
 package patreon
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -77,6 +79,134 @@ func TestFetchCampaign(t *testing.T) {
 	require.Equal(t, 1000, goal.Attributes.Amount)
 }
 
+func TestFetchCampaigns(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/campaigns", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "creation_name,vanity", request.URL.Query().Get("fields[campaign]"))
+		fmt.Fprint(writer, fetchCampaignsResp)
+	})
+
+	resp, err := client.FetchCampaigns(WithFields("campaign", "creation_name", "vanity"))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, "278915", resp.Data[0].ID)
+	require.Equal(t, "podsync", resp.Data[0].Attributes.Vanity)
+	require.Equal(t, "565132", resp.Data[1].ID)
+	require.Equal(t, 2, resp.Meta.Pagination.Total)
+}
+
+func TestFetchCampaignByID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/campaigns/278915", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "tiers,creator,benefits,goals", request.URL.Query().Get("include"))
+		fmt.Fprint(writer, fetchCampaignByIDResp)
+	})
+
+	resp, err := client.FetchCampaignByID("278915", WithIncludes(CampaignV2DefaultRelations))
+	require.NoError(t, err)
+
+	campaign := resp.Data
+	require.Equal(t, "278915", campaign.ID)
+	require.Equal(t, "new podcasting experience - Podsync", campaign.Attributes.CreationName)
+	require.Equal(t, "https://www.patreon.com/podsync", campaign.Attributes.URL)
+	require.True(t, campaign.Attributes.ShowEarnings)
+	require.NotEmpty(t, resp.Links.Self)
+
+	// Relationships
+
+	require.NotNil(t, campaign.Relationships.Tiers)
+	require.Len(t, campaign.Relationships.Tiers.Data, 1)
+	require.NotNil(t, campaign.Relationships.Benefits)
+	require.Len(t, campaign.Relationships.Benefits.Data, 1)
+	require.NotNil(t, campaign.Relationships.Goals)
+	require.Len(t, campaign.Relationships.Goals.Data, 1)
+	require.NotNil(t, campaign.Relationships.Creator)
+	require.Equal(t, "2822191", campaign.Relationships.Creator.Data.ID)
+
+	// Includes
+
+	require.Len(t, resp.Included.Items, 4)
+
+	tier, ok := resp.Included.Items[0].(*Tier)
+	require.True(t, ok)
+	require.Equal(t, "Supporter", tier.Attributes.Title)
+	require.NotNil(t, tier.Relationships.Benefits)
+
+	benefit, ok := resp.Included.Items[1].(*Benefit)
+	require.True(t, ok)
+	require.Equal(t, "Early access", benefit.Attributes.Title)
+	require.Equal(t, "custom", benefit.Attributes.BenefitType)
+	require.Equal(t, 1, benefit.Attributes.TiersCount)
+	require.JSONEq(t, `{"key": "value"}`, string(benefit.Attributes.AppMeta))
+
+	goal, ok := resp.Included.Items[2].(*Goal)
+	require.True(t, ok)
+	require.Equal(t, 20000, goal.Attributes.AmountCents)
+
+	user, ok := resp.Included.Items[3].(*User)
+	require.True(t, ok)
+	require.Equal(t, "2822191", user.ID)
+}
+
+func TestCampaignResponseResolvers(t *testing.T) {
+	resp := &CampaignResponse{}
+	require.NoError(t, json.Unmarshal([]byte(resolveCampaignResp), resp))
+
+	campaign := &resp.Data[0]
+
+	rewards, err := resp.RewardsOf(campaign)
+	require.NoError(t, err)
+	require.Len(t, rewards, 2)
+	require.Equal(t, "-1", rewards[0].ID)
+	require.Equal(t, "20", rewards[1].ID)
+
+	creator, err := resp.CreatorOf(campaign)
+	require.NoError(t, err)
+	require.Equal(t, "podsync", creator.Attributes.Vanity)
+
+	_, err = resp.GoalsOf(campaign)
+	require.True(t, errors.Is(err, ErrNotIncluded))
+
+	// Relationships were not requested
+	empty := &Campaign{}
+
+	rewards, err = resp.RewardsOf(empty)
+	require.NoError(t, err)
+	require.Empty(t, rewards)
+
+	creator, err = resp.CreatorOf(empty)
+	require.NoError(t, err)
+	require.Nil(t, creator)
+}
+
+const resolveCampaignResp = `
+{
+    "data": [
+        {
+            "attributes": {},
+            "id": "278915",
+            "relationships": {
+                "creator": {"data": {"id": "1", "type": "user"}},
+                "goals": {"data": [{"id": "40", "type": "goal"}, {"id": "41", "type": "goal"}]},
+                "rewards": {"data": [{"id": "-1", "type": "reward"}, {"id": "20", "type": "reward"}]}
+            },
+            "type": "campaign"
+        }
+    ],
+    "included": [
+        {"attributes": {"vanity": "podsync"}, "id": "1", "type": "user"},
+        {"attributes": {"description": "Everyone"}, "id": "-1", "type": "reward"},
+        {"attributes": {"amount_cents": 100}, "id": "20", "type": "reward"},
+        {"attributes": {"amount_cents": 1000}, "id": "40", "type": "goal"}
+    ]
+}
+`
+
 const fetchCampaignResp = `
 {
     "data": [
@@ -158,3 +288,99 @@ const fetchCampaignResp = `
     ]
 }
 `
+
+const fetchCampaignsResp = `
+{
+    "data": [
+        {
+            "attributes": {
+                "creation_name": "new podcasting experience - Podsync",
+                "vanity": "podsync"
+            },
+            "id": "278915",
+            "type": "campaign"
+        },
+        {
+            "attributes": {
+                "creation_name": "another campaign",
+                "vanity": null
+            },
+            "id": "565132",
+            "type": "campaign"
+        }
+    ],
+    "meta": {
+        "pagination": {
+            "cursors": {
+                "next": null
+            },
+            "total": 2
+        }
+    }
+}
+`
+
+const fetchCampaignByIDResp = `
+{
+    "data": {
+        "attributes": {
+            "creation_name": "new podcasting experience - Podsync",
+            "show_earnings": true,
+            "url": "https://www.patreon.com/podsync"
+        },
+        "id": "278915",
+        "relationships": {
+            "benefits": {
+                "data": [{"id": "51", "type": "benefit"}]
+            },
+            "creator": {
+                "data": {"id": "2822191", "type": "user"},
+                "links": {"related": "https://www.patreon.com/api/oauth2/v2/user/2822191"}
+            },
+            "goals": {
+                "data": [{"id": "2131231", "type": "goal"}]
+            },
+            "tiers": {
+                "data": [{"id": "12312312", "type": "tier"}]
+            }
+        },
+        "type": "campaign"
+    },
+    "included": [
+        {
+            "attributes": {"amount_cents": 100, "title": "Supporter"},
+            "id": "12312312",
+            "relationships": {
+                "benefits": {"data": [{"id": "51", "type": "benefit"}]}
+            },
+            "type": "tier"
+        },
+        {
+            "attributes": {
+                "app_meta": {"key": "value"},
+                "benefit_type": "custom",
+                "created_at": "2018-03-01T10:00:00+00:00",
+                "is_published": true,
+                "next_deliverable_due_date": null,
+                "tiers_count": 1,
+                "title": "Early access"
+            },
+            "id": "51",
+            "type": "benefit"
+        },
+        {
+            "attributes": {"amount_cents": 20000, "completed_percentage": 10, "title": "First goal"},
+            "id": "2131231",
+            "type": "goal"
+        },
+        {
+            "attributes": {},
+            "id": "2822191",
+            "type": "user"
+        }
+    ],
+    "links": {
+        "self": "https://www.patreon.com/api/oauth2/v2/campaigns/278915"
+    }
+}
+`
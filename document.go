@@ -0,0 +1,285 @@
+package patreon
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// documentSource keeps the JSON document a response was decoded from, see WithSourceDocuments.
+type documentSource struct {
+	raw json.RawMessage
+}
+
+func (s *documentSource) setSource(raw []byte) { s.raw = raw }
+func (s *documentSource) source() []byte       { return s.raw }
+
+// sourceKeeper is implemented by responses embedding documentSource.
+type sourceKeeper interface {
+	setSource(raw []byte)
+	source() []byte
+}
+
+// UnmarshalDocument decodes a JSON:API document (such as one returned by MarshalDocument) into the response v
+// and keeps the source document, like the client does with WithSourceDocuments.
+func UnmarshalDocument(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if keeper, ok := v.(sourceKeeper); ok {
+		keeper.setSource(append([]byte(nil), data...))
+	}
+
+	return nil
+}
+
+// MarshalDocument serializes an API response (such as *UserResponse, *CampaignResponse or *PledgeResponse)
+// back into a JSON:API document, so it can be cached, forwarded or stored as a test fixture and decoded later.
+//
+// If the response keeps its source document (see WithSourceDocuments and UnmarshalDocument), the output matches
+// the source: attributes absent from sparse fieldsets stay absent, nulls stay null and fields unknown to this package
+// are preserved, while values changed or cleared after decoding are taken from the response.
+//
+// Otherwise the document is built from the structs alone, which loses fields unknown to this package,
+// turns absent and null attributes into zero values and omits empty relationships, links and included array.
+func MarshalDocument(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if keeper, ok := v.(sourceKeeper); ok && len(keeper.source()) > 0 {
+		src, err := decodeDocument(keeper.source())
+		if err != nil {
+			return nil, err
+		}
+
+		base, err := baseDocument(v, keeper.source())
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(mergeValue(doc, src, base))
+	}
+
+	// Strip empty values the structs can't omit
+	switch data := doc["data"].(type) {
+	case map[string]interface{}:
+		cleanResource(data)
+	case []interface{}:
+		cleanResources(data)
+	}
+
+	if included, ok := doc["included"].([]interface{}); ok && len(included) > 0 {
+		cleanResources(included)
+	} else {
+		delete(doc, "included")
+	}
+
+	cleanLinks(doc)
+
+	return json.Marshal(doc)
+}
+
+// decodeDocument decodes JSON document into generic maps, keeping numbers as is.
+func decodeDocument(data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// baseDocument decodes the source document into a new response of the same type as v and encodes it back,
+// the result tells which source fields are known to the structs and how unchanged values are encoded.
+func baseDocument(v interface{}, raw []byte) (map[string]interface{}, error) {
+	fresh := reflect.New(reflect.Indirect(reflect.ValueOf(v)).Type()).Interface()
+	if includes, ok := includesOf(v); ok {
+		if freshIncludes, ok := includesOf(fresh); ok {
+			freshIncludes.types = includes.types
+		}
+	}
+
+	if err := json.Unmarshal(raw, fresh); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(fresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDocument(data)
+}
+
+// includesOf returns the 'Included' field of the response v.
+func includesOf(v interface{}) (*Includes, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	field := rv.FieldByName("Included")
+	if !field.IsValid() || !field.CanAddr() {
+		return nil, false
+	}
+
+	includes, ok := field.Addr().Interface().(*Includes)
+	return includes, ok
+}
+
+// mergeValue merges the encoded value enc with the source one src, base is src decoded and encoded back.
+// Values which didn't change after decoding (enc equals base) are taken from the source as is.
+func mergeValue(enc, src, base interface{}) interface{} {
+	if reflect.DeepEqual(enc, base) {
+		return src
+	}
+
+	switch enc := enc.(type) {
+	case map[string]interface{}:
+		src, srcOk := src.(map[string]interface{})
+		base, baseOk := base.(map[string]interface{})
+		if srcOk && baseOk {
+			return mergeObject(enc, src, base)
+		}
+	case []interface{}:
+		src, srcOk := src.([]interface{})
+		base, baseOk := base.([]interface{})
+		if srcOk && baseOk && len(src) == len(base) {
+			return mergeList(enc, src, base)
+		}
+	}
+
+	return enc
+}
+
+// mergeObject merges object fields. Source fields the structs don't know about are kept,
+// fields the structs cleared (omitted after decoding) are removed,
+// and fields absent in the source are added only if they were changed.
+func mergeObject(enc, src, base map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(src))
+	for key, value := range src {
+		encValue, encOk := enc[key]
+		baseValue, baseOk := base[key]
+
+		switch {
+		case encOk && baseOk:
+			out[key] = mergeValue(encValue, value, baseValue)
+		case encOk:
+			out[key] = encValue
+		case !baseOk:
+			out[key] = value
+		}
+	}
+
+	for key, value := range enc {
+		if _, ok := src[key]; ok {
+			continue
+		}
+
+		if baseValue, ok := base[key]; !ok || !reflect.DeepEqual(value, baseValue) {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// mergeList merges list items, resources and resource identifiers are matched by type and ID, other items by index.
+func mergeList(enc, src, base []interface{}) []interface{} {
+	out := make([]interface{}, len(enc))
+	for i, value := range enc {
+		j := findItem(base, value)
+		if j < 0 && len(enc) == len(base) {
+			j = i
+		}
+
+		if j >= 0 {
+			out[i] = mergeValue(value, src[j], base[j])
+		} else {
+			out[i] = value
+		}
+	}
+
+	return out
+}
+
+// findItem returns the index of the resource with the same type and ID as item, or -1.
+func findItem(list []interface{}, item interface{}) int {
+	obj, ok := item.(map[string]interface{})
+	if !ok || obj["type"] == nil || obj["id"] == nil {
+		return -1
+	}
+
+	for i, value := range list {
+		if other, ok := value.(map[string]interface{}); ok && other["type"] == obj["type"] && other["id"] == obj["id"] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func cleanResources(list []interface{}) {
+	for _, item := range list {
+		if res, ok := item.(map[string]interface{}); ok {
+			cleanResource(res)
+		}
+	}
+}
+
+// cleanResource removes relationships absent in the original document and empty links.
+func cleanResource(res map[string]interface{}) {
+	rels, ok := res["relationships"].(map[string]interface{})
+	if !ok {
+		delete(res, "relationships")
+		return
+	}
+
+	for name, value := range rels {
+		rel, ok := value.(map[string]interface{})
+		if !ok {
+			delete(rels, name)
+			continue
+		}
+
+		// Empty to-one relationship is decoded into zero Data, which stands for JSON:API null
+		if data, ok := rel["data"].(map[string]interface{}); ok && data["id"] == "" && data["type"] == "" {
+			rel["data"] = nil
+		}
+
+		cleanLinks(rel)
+	}
+
+	if len(rels) == 0 {
+		delete(res, "relationships")
+	}
+}
+
+// cleanLinks removes empty links and the links object itself if there are no links left.
+func cleanLinks(obj map[string]interface{}) {
+	links, ok := obj["links"].(map[string]interface{})
+	if !ok {
+		delete(obj, "links")
+		return
+	}
+
+	for name, link := range links {
+		if link == nil || link == "" {
+			delete(links, name)
+		}
+	}
+
+	if len(links) == 0 {
+		delete(obj, "links")
+	}
+}
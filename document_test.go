@@ -0,0 +1,193 @@
+package patreon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		new  func() interface{}
+	}{
+		{"user", currentUserResp, func() interface{} { return &UserResponse{} }},
+		{"identity", identityResp, func() interface{} { return &UserResponse{} }},
+		{"campaign", fetchCampaignResp, func() interface{} { return &CampaignResponse{} }},
+		{"campaigns", fetchCampaignsResp, func() interface{} { return &CampaignResponse{} }},
+		{"campaign by id", fetchCampaignByIDResp, func() interface{} { return &CampaignByIDResponse{} }},
+		{"pledges", fetchPledgesResp, func() interface{} { return &PledgeResponse{} }},
+		{"members", fetchMembersResp, func() interface{} { return &MembersResponse{} }},
+		{"member", fetchMemberResp, func() interface{} { return &MemberResponse{} }},
+		{"posts", fetchPostsResp, func() interface{} { return &PostsResponse{} }},
+		{"post", fetchPostResp, func() interface{} { return &PostResponse{} }},
+		{"webhook", webhookResp, func() interface{} { return &WebhookResponse{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.new()
+			require.NoError(t, UnmarshalDocument([]byte(tt.json), resp))
+
+			data, err := MarshalDocument(resp)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(data))
+		})
+	}
+}
+
+func TestMarshalDocumentChanges(t *testing.T) {
+	resp := &MembersResponse{}
+	require.NoError(t, UnmarshalDocument([]byte(fetchMembersResp), resp))
+
+	resp.Data[0].Attributes.PatronStatus = PatronStatusDeclined
+	resp.Data[0].Attributes.Note = "Changed"
+
+	data, err := MarshalDocument(resp)
+	require.NoError(t, err)
+
+	actual := &MembersResponse{}
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, PatronStatusDeclined, actual.Data[0].Attributes.PatronStatus)
+	require.Equal(t, "Changed", actual.Data[0].Attributes.Note)
+	require.Equal(t, resp.Meta, actual.Meta)
+
+	// Attributes absent from the sparse fieldset must stay absent
+	doc := struct {
+		Included []struct {
+			Attributes map[string]json.RawMessage `json:"attributes"`
+		} `json:"included"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Included, 3)
+	require.Equal(t, map[string]json.RawMessage{"vanity": json.RawMessage(`"podsync"`)}, doc.Included[0].Attributes)
+}
+
+func TestMarshalDocumentNulls(t *testing.T) {
+	const doc = `{
+		"data": [{"type": "member", "id": "m1", "attributes": {"full_name": null}}],
+		"meta": {"pagination": {"total": 1, "cursors": {"next": null}}}
+	}`
+
+	resp := &MembersResponse{}
+	require.NoError(t, UnmarshalDocument([]byte(doc), resp))
+
+	data, err := MarshalDocument(resp)
+	require.NoError(t, err)
+	require.JSONEq(t, doc, string(data))
+}
+
+func TestMarshalDocumentRemovedRelationship(t *testing.T) {
+	resp := &CampaignResponse{}
+	require.NoError(t, UnmarshalDocument([]byte(resolveCampaignResp), resp))
+
+	resp.Data[0].Relationships.Goals = nil
+
+	data, err := MarshalDocument(resp)
+	require.NoError(t, err)
+
+	actual := &CampaignResponse{}
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Nil(t, actual.Data[0].Relationships.Goals)
+	require.Equal(t, resp.Data[0].Relationships.Rewards, actual.Data[0].Relationships.Rewards)
+	require.Equal(t, resp.Data[0].Relationships.Creator, actual.Data[0].Relationships.Creator)
+}
+
+func TestMarshalDocumentSourceDocuments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/campaigns/278915/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, fetchMembersResp)
+	})
+
+	resp, err := client.FetchMembers("278915")
+	require.NoError(t, err)
+	require.Empty(t, resp.source())
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithSourceDocuments())
+
+	resp, err = client.FetchMembers("278915")
+	require.NoError(t, err)
+
+	data, err := MarshalDocument(resp)
+	require.NoError(t, err)
+	require.JSONEq(t, fetchMembersResp, string(data))
+}
+
+func TestMarshalDocument(t *testing.T) {
+	resp := &PledgeResponse{}
+	require.NoError(t, json.Unmarshal([]byte(fetchPledgesResp), resp))
+
+	data, err := MarshalDocument(resp)
+	require.NoError(t, err)
+
+	doc := struct {
+		Data []struct {
+			Relationships map[string]json.RawMessage `json:"relationships"`
+		} `json:"data"`
+		Included []json.RawMessage          `json:"included"`
+		Links    map[string]json.RawMessage `json:"links"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.NotEmpty(t, doc.Data)
+	require.Len(t, doc.Included, len(resp.Included.Items))
+
+	for _, item := range doc.Data {
+		for name, rel := range item.Relationships {
+			require.NotEqual(t, "null", string(rel), name)
+		}
+	}
+
+	for name, link := range doc.Links {
+		require.NotEqual(t, `""`, string(link), name)
+	}
+
+	decoded := &PledgeResponse{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	require.Equal(t, resp.Data[0].ID, decoded.Data[0].ID)
+	require.Equal(t, resp.Data[0].Attributes.AmountCents, decoded.Data[0].Attributes.AmountCents)
+	require.True(t, resp.Data[0].Attributes.CreatedAt.Equal(decoded.Data[0].Attributes.CreatedAt.Time))
+	require.Equal(t, resp.Links, decoded.Links)
+
+	require.Nil(t, decoded.Data[0].Relationships.Patron)
+	require.Equal(t, resp.Data[1].Relationships.Patron, decoded.Data[1].Relationships.Patron)
+	require.Equal(t, resp.Data[1].Relationships.Reward, decoded.Data[1].Relationships.Reward)
+}
+
+func TestMarshalDocumentEmpty(t *testing.T) {
+	data, err := MarshalDocument(&UserResponse{})
+	require.NoError(t, err)
+
+	doc := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Contains(t, doc, "data")
+	require.NotContains(t, doc, "included")
+	require.NotContains(t, doc, "links")
+}
+
+func TestIncludesMarshalJSON(t *testing.T) {
+	includes := Includes{}
+	require.NoError(t, json.Unmarshal([]byte(includesJson), &includes))
+
+	data, err := json.Marshal(includes)
+	require.NoError(t, err)
+
+	actual := Includes{}
+	require.NoError(t, json.Unmarshal(data, &actual))
+	require.Len(t, actual.Items, len(includes.Items))
+
+	roundTrip, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(roundTrip))
+
+	data, err = json.Marshal(Includes{})
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(data))
+}
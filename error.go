@@ -1,13 +1,54 @@
 package patreon
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrUnauthorized matches API errors with HTTP 401 status code (missing or expired access token).
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden matches API errors with HTTP 403 status code (insufficient scopes).
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound matches API errors with HTTP 404 status code.
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited matches API errors with HTTP 429 status code.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServer matches API errors with HTTP 5xx status codes.
+	ErrServer = errors.New("server error")
+)
+
+// ErrNotIncluded is returned by relationship resolvers when a referenced resource is missing from response includes.
+// Make sure the relationship is requested with WithIncludes.
+var ErrNotIncluded = errors.New("resource is not included")
+
 // Error describes error details.
 type Error struct {
-	Code     int    `json:"code"`
-	CodeName string `json:"code_name"`
-	Detail   string `json:"detail"`
-	ID       string `json:"id"`
-	Status   string `json:"status"`
-	Title    string `json:"title"`
+	Code              int    `json:"code"`
+	CodeName          string `json:"code_name"`
+	Detail            string `json:"detail"`
+	ID                string `json:"id"`
+	Status            string `json:"status"`
+	Title             string `json:"title"`
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+}
+
+// Temporary reports whether the error is transient (such as throttling or server failure),
+// so the request may succeed if retried later.
+func (e Error) Temporary() bool {
+	if e.CodeName == "RequestThrottled" {
+		return true
+	}
+
+	status, err := strconv.Atoi(e.Status)
+	return err == nil && isRetryableStatus(status)
 }
 
 // ErrorResponse is a Patreon error response.
@@ -23,3 +64,77 @@ func (e ErrorResponse) Error() string {
 
 	return "(ERR)"
 }
+
+// Temporary reports whether any of the errors is transient.
+func (e ErrorResponse) Temporary() bool {
+	for _, err := range e.Errors {
+		if err.Temporary() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAfter returns the longest delay suggested by the errors.
+func (e ErrorResponse) retryAfter() time.Duration {
+	wait := 0
+	for _, err := range e.Errors {
+		if err.RetryAfterSeconds > wait {
+			wait = err.RetryAfterSeconds
+		}
+	}
+
+	return time.Duration(wait) * time.Second
+}
+
+// APIError is returned by Client when Patreon responds with non-successful HTTP status code.
+// Use errors.Is with ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited and ErrServer to classify it.
+// Errors is empty if the response body is not a Patreon error response (for instance, HTML page from a proxy).
+type APIError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Errors     []Error
+}
+
+func (e *APIError) Error() string {
+	// In most cases there is only one error
+	if len(e.Errors) > 0 && e.Errors[0].Detail != "" {
+		return e.Errors[0].Detail
+	}
+
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether the error matches one of the sentinel errors by HTTP status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500 && e.StatusCode <= 599
+	}
+
+	return false
+}
+
+// Unwrap returns parsed errors as ErrorResponse, so it can be extracted with errors.As.
+func (e *APIError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return ErrorResponse{Errors: e.Errors}
+}
+
+// Temporary reports whether the request may succeed if retried later.
+func (e *APIError) Temporary() bool {
+	return isRetryableStatus(e.StatusCode) || ErrorResponse{Errors: e.Errors}.Temporary()
+}
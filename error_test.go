@@ -1,6 +1,7 @@
 package patreon
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -21,8 +22,15 @@ func TestErrorResponse(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, "The server could not verify that you are authorized to access the URL requested.", err.Error())
 
-	errResp, ok := err.(ErrorResponse)
+	apiErr, ok := err.(*APIError)
 	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.JSONEq(t, errorResp, string(apiErr.Body))
+	require.True(t, errors.Is(err, ErrForbidden))
+	require.False(t, errors.Is(err, ErrUnauthorized))
+
+	errResp := ErrorResponse{}
+	require.True(t, errors.As(err, &errResp))
 	require.Equal(t, 1, len(errResp.Errors))
 	require.Equal(t, 1, errResp.Errors[0].Code)
 	require.Equal(t, "Unauthorized", errResp.Errors[0].CodeName)
@@ -37,6 +45,52 @@ func TestDefaultErrorString(t *testing.T) {
 	require.Equal(t, "(ERR)", err.Error())
 }
 
+func TestNonJSONErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html")
+		writer.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(writer, "<html>Bad Gateway</html>")
+	})
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Equal(t, "502 Bad Gateway", err.Error())
+	require.True(t, errors.Is(err, ErrServer))
+
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	require.Equal(t, "text/html", apiErr.Header.Get("Content-Type"))
+	require.Equal(t, "<html>Bad Gateway</html>", string(apiErr.Body))
+	require.Empty(t, apiErr.Errors)
+	require.False(t, errors.As(err, &ErrorResponse{}))
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusServiceUnavailable, ErrServer},
+	}
+
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		require.True(t, errors.Is(err, tt.target), tt.status)
+		require.False(t, errors.Is(err, ErrNotIncluded), tt.status)
+	}
+
+	require.False(t, errors.Is(&APIError{StatusCode: http.StatusBadRequest}, ErrServer))
+}
+
 const errorResp = `
 {
     "errors": [
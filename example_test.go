@@ -39,24 +39,13 @@ func Example_fetchPatronsAndPledges() {
 			panic(err)
 		}
 
-		// Get all the users in an easy-to-lookup way
-		users := make(map[string]*User)
-		for _, item := range pledgesResponse.Included.Items {
-			u, ok := item.(*User)
-			if !ok {
-				continue
-			}
-
-			users[u.ID] = u
-		}
-
 		fmt.Printf("Page %d\r\n", page)
 
 		// Loop over the pledges to get e.g. their amount and user name
 		for _, pledge := range pledgesResponse.Data {
 			amount := pledge.Attributes.AmountCents
 			patronId := pledge.Relationships.Patron.Data.ID
-			patronFullName := users[patronId].Attributes.FullName
+			patronFullName := pledgesResponse.Included.User(patronId).Attributes.FullName
 
 			fmt.Printf("%s is pledging %d cents\r\n", patronFullName, amount)
 		}
@@ -0,0 +1,8 @@
+module github.com/mxpv/patreon-go
+
+go 1.16
+
+require (
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/oauth2 v0.0.0-20210323180902-22b0adad7558
+)
@@ -3,11 +3,24 @@ package patreon
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 )
 
 // Includes wraps 'includes' JSON field to handle objects of different type within an array.
+// Use Find or typed helpers (such as User and Reward) to look up items referenced by relationships.
+// Resources of types unknown to this library are decoded into RawResource.
 type Includes struct {
 	Items []interface{}
+
+	// Strict makes UnmarshalJSON fail on resources of unknown types instead of decoding them into RawResource.
+	// It must be set before unmarshalling, this is useful in tests to detect API changes.
+	Strict bool `json:"-"`
+
+	// index maps type and ID to the item, it's built once on unmarshal
+	index map[Data]interface{}
+
+	// types overrides resource types registered globally, see WithResourceType
+	types map[string]func() interface{}
 }
 
 // UnmarshalJSON deserializes 'includes' field into the appropriate structs depending on the 'type' field.
@@ -20,12 +33,10 @@ func (i *Includes) UnmarshalJSON(b []byte) error {
 
 	count := len(items)
 	i.Items = make([]interface{}, count)
-
-	s := struct {
-		Type string `json:"type"`
-	}{}
+	i.index = make(map[Data]interface{}, count)
 
 	for idx, raw := range items {
+		s := Data{}
 		if err := json.Unmarshal(*raw, &s); err != nil {
 			return err
 		}
@@ -34,22 +45,12 @@ func (i *Includes) UnmarshalJSON(b []byte) error {
 
 		// Depending on the type, we can run json.Unmarshal again on the same byte slice
 		// But this time, we'll pass in the appropriate struct instead of a map
-		if s.Type == "user" {
-			obj = &User{}
-		} else if s.Type == "reward" {
-			obj = &Reward{}
-		} else if s.Type == "goal" {
-			obj = &Goal{}
-		} else if s.Type == "campaign" {
-			obj = &Campaign{}
-		} else if s.Type == "pledge" {
-			obj = &Pledge{}
-		} else if s.Type == "card" {
-			obj = &Card{}
-		} else if s.Type == "address" {
-			obj = &Address{}
-		} else {
+		if factory := i.factory(s.Type); factory != nil {
+			obj = factory()
+		} else if i.Strict {
 			return fmt.Errorf("unsupported type '%s'", s.Type)
+		} else {
+			obj = &RawResource{}
 		}
 
 		if err := json.Unmarshal(*raw, obj); err != nil {
@@ -57,7 +58,239 @@ func (i *Includes) UnmarshalJSON(b []byte) error {
 		}
 
 		i.Items[idx] = obj
+		i.index[s] = obj
+	}
+
+	return nil
+}
+
+// MarshalJSON serializes included items back into JSON:API 'included' array.
+func (i Includes) MarshalJSON() ([]byte, error) {
+	if len(i.Items) == 0 {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(i.Items)
+}
+
+// factory returns the function creating structs for resources of the given type, or nil if the type is unknown.
+func (i *Includes) factory(name string) func() interface{} {
+	if factory, ok := i.types[name]; ok {
+		return factory
+	}
+
+	return lookupResourceType(name)
+}
+
+// RawResource represents an included resource of a type not known to this library (such as 'pledge_vat_location'),
+// so it's preserved as is instead of failing the whole response.
+type RawResource struct {
+	Type          string          `json:"type"`
+	ID            string          `json:"id"`
+	Attributes    json.RawMessage `json:"attributes"`
+	Relationships json.RawMessage `json:"relationships,omitempty"`
+}
+
+// Find returns the included item referenced by data, or nil if it's not included.
+func (i *Includes) Find(data Data) interface{} {
+	if i.index != nil {
+		return i.index[data]
+	}
+
+	// Items were populated manually, fallback to linear search
+	for _, item := range i.Items {
+		if d, ok := resourceData(item); ok && d == data {
+			return item
+		}
 	}
 
 	return nil
 }
+
+// resolve returns the included item referenced by data, reporting missing items with ErrNotIncluded.
+func (i *Includes) resolve(data Data) (interface{}, error) {
+	item := i.Find(data)
+	if item == nil {
+		return nil, fmt.Errorf("%s '%s': %w", data.Type, data.ID, ErrNotIncluded)
+	}
+
+	return item, nil
+}
+
+// unexpectedType reports an included item decoded into an unexpected struct.
+func unexpectedType(data Data, item interface{}) error {
+	return fmt.Errorf("%s '%s' has unexpected type %T", data.Type, data.ID, item)
+}
+
+// User returns the included user with the given ID, or nil if it's not included.
+func (i *Includes) User(id string) *User {
+	user, _ := i.Find(Data{ID: id, Type: "user"}).(*User)
+	return user
+}
+
+// Reward returns the included reward with the given ID, or nil if it's not included.
+func (i *Includes) Reward(id string) *Reward {
+	reward, _ := i.Find(Data{ID: id, Type: "reward"}).(*Reward)
+	return reward
+}
+
+// Goal returns the included goal with the given ID, or nil if it's not included.
+func (i *Includes) Goal(id string) *Goal {
+	goal, _ := i.Find(Data{ID: id, Type: "goal"}).(*Goal)
+	return goal
+}
+
+// Campaign returns the included campaign with the given ID, or nil if it's not included.
+func (i *Includes) Campaign(id string) *Campaign {
+	campaign, _ := i.Find(Data{ID: id, Type: "campaign"}).(*Campaign)
+	return campaign
+}
+
+// Pledge returns the included pledge with the given ID, or nil if it's not included.
+func (i *Includes) Pledge(id string) *Pledge {
+	pledge, _ := i.Find(Data{ID: id, Type: "pledge"}).(*Pledge)
+	return pledge
+}
+
+// Address returns the included address with the given ID, or nil if it's not included.
+func (i *Includes) Address(id string) *Address {
+	address, _ := i.Find(Data{ID: id, Type: "address"}).(*Address)
+	return address
+}
+
+// Member returns the included member with the given ID, or nil if it's not included.
+func (i *Includes) Member(id string) *Member {
+	member, _ := i.Find(Data{ID: id, Type: "member"}).(*Member)
+	return member
+}
+
+// Tier returns the included tier with the given ID, or nil if it's not included.
+func (i *Includes) Tier(id string) *Tier {
+	tier, _ := i.Find(Data{ID: id, Type: "tier"}).(*Tier)
+	return tier
+}
+
+// Users returns all the included users.
+func (i *Includes) Users() []*User {
+	var users []*User
+	for _, item := range i.Items {
+		if user, ok := item.(*User); ok {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
+
+// Rewards returns all the included rewards.
+func (i *Includes) Rewards() []*Reward {
+	var rewards []*Reward
+	for _, item := range i.Items {
+		if reward, ok := item.(*Reward); ok {
+			rewards = append(rewards, reward)
+		}
+	}
+
+	return rewards
+}
+
+// Goals returns all the included goals.
+func (i *Includes) Goals() []*Goal {
+	var goals []*Goal
+	for _, item := range i.Items {
+		if goal, ok := item.(*Goal); ok {
+			goals = append(goals, goal)
+		}
+	}
+
+	return goals
+}
+
+// Campaigns returns all the included campaigns.
+func (i *Includes) Campaigns() []*Campaign {
+	var campaigns []*Campaign
+	for _, item := range i.Items {
+		if campaign, ok := item.(*Campaign); ok {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	return campaigns
+}
+
+// Pledges returns all the included pledges.
+func (i *Includes) Pledges() []*Pledge {
+	var pledges []*Pledge
+	for _, item := range i.Items {
+		if pledge, ok := item.(*Pledge); ok {
+			pledges = append(pledges, pledge)
+		}
+	}
+
+	return pledges
+}
+
+// Members returns all the included members.
+func (i *Includes) Members() []*Member {
+	var members []*Member
+	for _, item := range i.Items {
+		if member, ok := item.(*Member); ok {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+// Tiers returns all the included tiers.
+func (i *Includes) Tiers() []*Tier {
+	var tiers []*Tier
+	for _, item := range i.Items {
+		if tier, ok := item.(*Tier); ok {
+			tiers = append(tiers, tier)
+		}
+	}
+
+	return tiers
+}
+
+// add appends the item unless an item with the same type and ID is already included.
+func (i *Includes) add(item interface{}) {
+	data, ok := resourceData(item)
+	if !ok {
+		i.Items = append(i.Items, item)
+		return
+	}
+
+	if i.index == nil {
+		i.index = make(map[Data]interface{}, len(i.Items))
+		for _, existing := range i.Items {
+			if d, ok := resourceData(existing); ok {
+				i.index[d] = existing
+			}
+		}
+	}
+
+	if _, exists := i.index[data]; exists {
+		return
+	}
+
+	i.Items = append(i.Items, item)
+	i.index[data] = item
+}
+
+// resourceData returns the type and ID of an included item.
+func resourceData(item interface{}) (Data, bool) {
+	v := reflect.Indirect(reflect.ValueOf(item))
+	if v.Kind() != reflect.Struct {
+		return Data{}, false
+	}
+
+	id := v.FieldByName("ID")
+	typ := v.FieldByName("Type")
+	if id.Kind() != reflect.String || typ.Kind() != reflect.String {
+		return Data{}, false
+	}
+
+	return Data{ID: id.String(), Type: typ.String()}, true
+}
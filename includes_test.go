@@ -2,6 +2,8 @@ package patreon
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -63,12 +65,97 @@ func TestParseIncludes(t *testing.T) {
 }
 
 func TestParseUnsupportedInclude(t *testing.T) {
-	includes := Includes{}
+	includes := Includes{Strict: true}
 	err := json.Unmarshal([]byte(unknownIncludeJson), &includes)
 	require.Error(t, err)
 	require.Equal(t, "unsupported type 'unknown'", err.Error())
 }
 
+func TestParseUnknownIncludeAsRawResource(t *testing.T) {
+	includes := Includes{}
+	err := json.Unmarshal([]byte(unknownIncludeJson), &includes)
+	require.NoError(t, err)
+	require.Len(t, includes.Items, 2)
+
+	user, ok := includes.Items[0].(*User)
+	require.True(t, ok)
+	require.Equal(t, "podsync", user.Attributes.Vanity)
+
+	raw, ok := includes.Items[1].(*RawResource)
+	require.True(t, ok)
+	require.Equal(t, "unknown", raw.Type)
+	require.Equal(t, "12312312", raw.ID)
+	require.JSONEq(t, `{"country": "UA"}`, string(raw.Attributes))
+	require.JSONEq(t, `{}`, string(raw.Relationships))
+
+	require.Equal(t, raw, includes.Find(Data{ID: "12312312", Type: "unknown"}))
+}
+
+func TestClientStrictIncludes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/api/campaigns/123/pledges", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"data": [], "included": %s}`, unknownIncludeJson)
+	})
+
+	resp, err := client.FetchPledges("123")
+	require.NoError(t, err)
+	require.Len(t, resp.Included.Items, 2)
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithStrictIncludes())
+
+	_, err = client.FetchPledges("123")
+	require.Error(t, err)
+}
+
+func TestIncludesLookup(t *testing.T) {
+	includes := Includes{}
+	err := json.Unmarshal([]byte(includesJson), &includes)
+	require.NoError(t, err)
+
+	user := includes.User("2822191")
+	require.NotNil(t, user)
+	require.Equal(t, "podsync", user.Attributes.Vanity)
+	require.Nil(t, includes.User("12312312"))
+
+	reward := includes.Reward("12312312")
+	require.NotNil(t, reward)
+	require.Equal(t, 100, reward.Attributes.Amount)
+
+	require.NotNil(t, includes.Goal("2131231"))
+	require.NotNil(t, includes.Campaign("12312321"))
+	require.Nil(t, includes.Member("2822191"))
+
+	card, ok := includes.Find(Data{ID: "bt_12312312", Type: "card"}).(*Card)
+	require.True(t, ok)
+	require.Equal(t, "PayPal", card.Attributes.CardType)
+	require.Nil(t, includes.Find(Data{ID: "bt_12312312", Type: "user"}))
+
+	require.Len(t, includes.Users(), 1)
+	require.Len(t, includes.Rewards(), 1)
+	require.Len(t, includes.Goals(), 1)
+	require.Len(t, includes.Campaigns(), 1)
+	require.Len(t, includes.Pledges(), 1)
+	require.Empty(t, includes.Tiers())
+}
+
+func TestIncludesLookupWithoutIndex(t *testing.T) {
+	user := &User{ID: "1", Type: "user"}
+	tier := &Tier{ID: "1", Type: "tier"}
+
+	includes := Includes{Items: []interface{}{user, tier}}
+	require.Equal(t, user, includes.User("1"))
+	require.Equal(t, tier, includes.Tier("1"))
+	require.Nil(t, includes.Reward("1"))
+
+	includes.add(&User{ID: "1", Type: "user"})
+	includes.add(&User{ID: "2", Type: "user"})
+	require.Len(t, includes.Items, 3)
+	require.Equal(t, user, includes.User("1"))
+	require.Equal(t, "2", includes.User("2").ID)
+}
+
 const includesJson = `
 [
 	{
@@ -187,7 +274,9 @@ const unknownIncludeJson = `
 		"type": "user"
 	},
 	{
-		"attributes": {},
+		"attributes": {
+			"country": "UA"
+		},
 		"id": "12312312",
 		"relationships": {},
 		"type": "unknown"
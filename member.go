@@ -0,0 +1,70 @@
+package patreon
+
+// MemberDefaultRelations specifies default includes for Member.
+const MemberDefaultRelations = "user,currently_entitled_tiers,address"
+
+const (
+	// PatronStatusActive specifies a member with an active pledge.
+	PatronStatusActive = "active_patron"
+
+	// PatronStatusDeclined specifies a member whose last payment was declined.
+	PatronStatusDeclined = "declined_patron"
+
+	// PatronStatusFormer specifies a member who has deleted their pledge.
+	PatronStatusFormer = "former_patron"
+)
+
+// Member represents the record of a user's membership to a campaign (API v2).
+// Valid relationships: address, campaign, currently_entitled_tiers, user, pledge_history.
+type Member struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		CampaignLifetimeSupportCents int      `json:"campaign_lifetime_support_cents"`
+		CurrentlyEntitledAmountCents int      `json:"currently_entitled_amount_cents"`
+		Email                        string   `json:"email"`
+		FullName                     string   `json:"full_name"`
+		IsFollower                   bool     `json:"is_follower"`
+		LastChargeDate               NullTime `json:"last_charge_date"`
+		LastChargeStatus             string   `json:"last_charge_status"`
+		LifetimeSupportCents         int      `json:"lifetime_support_cents"`
+		NextChargeDate               NullTime `json:"next_charge_date"`
+		Note                         string   `json:"note"`
+		PatronStatus                 string   `json:"patron_status"`
+		PledgeCadence                int      `json:"pledge_cadence"`
+		PledgeRelationshipStart      NullTime `json:"pledge_relationship_start"`
+		WillPayAmountCents           int      `json:"will_pay_amount_cents"`
+	} `json:"attributes"`
+	Relationships struct {
+		User                   *UserRelationship          `json:"user,omitempty"`
+		Campaign               *CampaignRelationship      `json:"campaign,omitempty"`
+		CurrentlyEntitledTiers *TiersRelationship         `json:"currently_entitled_tiers,omitempty"`
+		Address                *AddressRelationship       `json:"address,omitempty"`
+		PledgeHistory          *PledgeHistoryRelationship `json:"pledge_history,omitempty"`
+	} `json:"relationships"`
+}
+
+// MemberResponse wraps Patreon's fetch member API response
+type MemberResponse struct {
+	Data     Member   `json:"data"`
+	Included Includes `json:"included"`
+	Links    struct {
+		Self string `json:"self"`
+	} `json:"links"`
+
+	documentSource
+}
+
+// MembersResponse wraps Patreon's campaign members API response
+type MembersResponse struct {
+	Data     []Member `json:"data"`
+	Included Includes `json:"included"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Meta struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"meta"`
+
+	documentSource
+}
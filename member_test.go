@@ -0,0 +1,296 @@
+package patreon
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/campaigns/278915/members", func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		require.Equal(t, "user,currently_entitled_tiers,address", query.Get("include"))
+		require.Equal(t, "patron_status,currently_entitled_amount_cents,next_charge_date", query.Get("fields[member]"))
+		require.Equal(t, "10", query.Get("page[count]"))
+		require.Equal(t, "eyJ2IjoxfQ", query.Get("page[cursor]"))
+		fmt.Fprint(writer, fetchMembersResp)
+	})
+
+	resp, err := client.FetchMembers("278915",
+		WithIncludes(MemberDefaultRelations),
+		WithFields("member", "patron_status", "currently_entitled_amount_cents", "next_charge_date"),
+		WithPageSize(10),
+		WithCursor("eyJ2IjoxfQ"))
+	require.NoError(t, err)
+
+	require.Equal(t, 55, resp.Meta.Pagination.Total)
+	require.Equal(t, "eyJ2IjoyfQ", resp.Meta.Pagination.Cursors.Next)
+	require.NotEmpty(t, resp.Links.Next)
+
+	// Attributes
+
+	require.Len(t, resp.Data, 1)
+	member := resp.Data[0]
+	require.Equal(t, "member", member.Type)
+	require.Equal(t, "03ca69c3-ebea-4b9a-8fac-e4a837873254", member.ID)
+
+	attrs := member.Attributes
+	require.Equal(t, 300, attrs.CampaignLifetimeSupportCents)
+	require.Equal(t, 100, attrs.CurrentlyEntitledAmountCents)
+	require.Equal(t, "max@gmail.com", attrs.Email)
+	require.Equal(t, "Max", attrs.FullName)
+	require.False(t, attrs.IsFollower)
+	require.True(t, attrs.LastChargeDate.Valid)
+	require.Equal(t, "Paid", attrs.LastChargeStatus)
+	require.Equal(t, 300, attrs.LifetimeSupportCents)
+	require.True(t, attrs.NextChargeDate.Valid)
+	require.Equal(t, "Bought a t-shirt", attrs.Note)
+	require.Equal(t, PatronStatusActive, attrs.PatronStatus)
+	require.Equal(t, 1, attrs.PledgeCadence)
+	require.True(t, attrs.PledgeRelationshipStart.Valid)
+	require.Equal(t, 100, attrs.WillPayAmountCents)
+
+	// Relationships
+
+	require.NotNil(t, member.Relationships.User)
+	require.Equal(t, "2822191", member.Relationships.User.Data.ID)
+
+	require.NotNil(t, member.Relationships.CurrentlyEntitledTiers)
+	require.Len(t, member.Relationships.CurrentlyEntitledTiers.Data, 1)
+	require.Equal(t, "12312312", member.Relationships.CurrentlyEntitledTiers.Data[0].ID)
+	require.Equal(t, "tier", member.Relationships.CurrentlyEntitledTiers.Data[0].Type)
+
+	require.NotNil(t, member.Relationships.Address)
+	require.Equal(t, "1234", member.Relationships.Address.Data.ID)
+
+	// Includes
+
+	require.Len(t, resp.Included.Items, 3)
+
+	user, ok := resp.Included.Items[0].(*User)
+	require.True(t, ok)
+	require.Equal(t, "podsync", user.Attributes.Vanity)
+
+	tier, ok := resp.Included.Items[1].(*Tier)
+	require.True(t, ok)
+	require.Equal(t, 100, tier.Attributes.AmountCents)
+	require.Equal(t, "Supporter", tier.Attributes.Title)
+	require.Equal(t, []string{"12345"}, tier.Attributes.DiscordRoleIDs)
+	require.Nil(t, tier.Attributes.UserLimit)
+
+	address, ok := resp.Included.Items[2].(*Address)
+	require.True(t, ok)
+	require.Equal(t, "Kyiv", address.Attributes.City)
+	require.True(t, address.Attributes.CreatedAt.Valid)
+}
+
+func TestFetchMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/members/03ca69c3-ebea-4b9a-8fac-e4a837873254", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "user,campaign,pledge_history", request.URL.Query().Get("include"))
+		fmt.Fprint(writer, fetchMemberResp)
+	})
+
+	resp, err := client.FetchMember("03ca69c3-ebea-4b9a-8fac-e4a837873254", WithIncludes("user", "campaign", "pledge_history"))
+	require.NoError(t, err)
+
+	require.Equal(t, "03ca69c3-ebea-4b9a-8fac-e4a837873254", resp.Data.ID)
+	require.Equal(t, PatronStatusDeclined, resp.Data.Attributes.PatronStatus)
+	require.NotEmpty(t, resp.Links.Self)
+
+	history := resp.Data.Relationships.PledgeHistory
+	require.NotNil(t, history)
+	require.Len(t, history.Data, 1)
+	require.Equal(t, "pledge-event", history.Data[0].Type)
+
+	require.NotNil(t, resp.Data.Relationships.Campaign)
+	require.Equal(t, "278915", resp.Data.Relationships.Campaign.Data.ID)
+
+	// Includes
+
+	require.Len(t, resp.Included.Items, 3)
+
+	_, ok := resp.Included.Items[0].(*User)
+	require.True(t, ok)
+
+	_, ok = resp.Included.Items[1].(*Campaign)
+	require.True(t, ok)
+
+	event, ok := resp.Included.Items[2].(*PledgeEvent)
+	require.True(t, ok)
+	require.Equal(t, "pledge_upgrade", event.Attributes.Type)
+	require.Equal(t, 500, event.Attributes.AmountCents)
+	require.Equal(t, "USD", event.Attributes.CurrencyCode)
+	require.True(t, event.Attributes.Date.Valid)
+	require.Equal(t, "Declined", event.Attributes.PaymentStatus)
+	require.Equal(t, "12312312", event.Attributes.TierID)
+	require.NotNil(t, event.Relationships.Tier)
+	require.Equal(t, "12312312", event.Relationships.Tier.Data.ID)
+}
+
+const fetchMembersResp = `
+{
+    "data": [
+        {
+            "attributes": {
+                "campaign_lifetime_support_cents": 300,
+                "currently_entitled_amount_cents": 100,
+                "email": "max@gmail.com",
+                "full_name": "Max",
+                "is_follower": false,
+                "last_charge_date": "2018-04-01T21:28:06+00:00",
+                "last_charge_status": "Paid",
+                "lifetime_support_cents": 300,
+                "next_charge_date": "2018-05-01T00:00:00+00:00",
+                "note": "Bought a t-shirt",
+                "patron_status": "active_patron",
+                "pledge_cadence": 1,
+                "pledge_relationship_start": "2018-02-01T14:13:29.525740+00:00",
+                "will_pay_amount_cents": 100
+            },
+            "id": "03ca69c3-ebea-4b9a-8fac-e4a837873254",
+            "relationships": {
+                "address": {
+                    "data": {
+                        "id": "1234",
+                        "type": "address"
+                    }
+                },
+                "currently_entitled_tiers": {
+                    "data": [
+                        {
+                            "id": "12312312",
+                            "type": "tier"
+                        }
+                    ]
+                },
+                "user": {
+                    "data": {
+                        "id": "2822191",
+                        "type": "user"
+                    },
+                    "links": {
+                        "related": "https://www.patreon.com/api/oauth2/v2/user/2822191"
+                    }
+                }
+            },
+            "type": "member"
+        }
+    ],
+    "included": [
+        {
+            "attributes": {
+                "vanity": "podsync"
+            },
+            "id": "2822191",
+            "type": "user"
+        },
+        {
+            "attributes": {
+                "amount_cents": 100,
+                "discord_role_ids": ["12345"],
+                "title": "Supporter",
+                "user_limit": null
+            },
+            "id": "12312312",
+            "type": "tier"
+        },
+        {
+            "attributes": {
+                "city": "Kyiv",
+                "created_at": "2018-02-01T14:13:29+00:00"
+            },
+            "id": "1234",
+            "type": "address"
+        }
+    ],
+    "links": {
+        "next": "https://www.patreon.com/api/oauth2/v2/campaigns/278915/members?page%5Bcount%5D=10&page%5Bcursor%5D=eyJ2IjoyfQ"
+    },
+    "meta": {
+        "pagination": {
+            "cursors": {
+                "next": "eyJ2IjoyfQ"
+            },
+            "total": 55
+        }
+    }
+}
+`
+
+const fetchMemberResp = `
+{
+    "data": {
+        "attributes": {
+            "patron_status": "declined_patron"
+        },
+        "id": "03ca69c3-ebea-4b9a-8fac-e4a837873254",
+        "relationships": {
+            "campaign": {
+                "data": {
+                    "id": "278915",
+                    "type": "campaign"
+                }
+            },
+            "pledge_history": {
+                "data": [
+                    {
+                        "id": "subscription:123456",
+                        "type": "pledge-event"
+                    }
+                ]
+            },
+            "user": {
+                "data": {
+                    "id": "2822191",
+                    "type": "user"
+                }
+            }
+        },
+        "type": "member"
+    },
+    "included": [
+        {
+            "attributes": {},
+            "id": "2822191",
+            "type": "user"
+        },
+        {
+            "attributes": {},
+            "id": "278915",
+            "type": "campaign"
+        },
+        {
+            "attributes": {
+                "amount_cents": 500,
+                "currency_code": "USD",
+                "date": "2018-05-01T00:00:00+00:00",
+                "payment_status": "Declined",
+                "tier_id": "12312312",
+                "tier_title": "Supporter",
+                "type": "pledge_upgrade"
+            },
+            "id": "subscription:123456",
+            "relationships": {
+                "tier": {
+                    "data": {
+                        "id": "12312312",
+                        "type": "tier"
+                    }
+                }
+            },
+            "type": "pledge-event"
+        }
+    ],
+    "links": {
+        "self": "https://www.patreon.com/api/oauth2/v2/members/03ca69c3-ebea-4b9a-8fac-e4a837873254"
+    }
+}
+`
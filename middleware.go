@@ -0,0 +1,91 @@
+package patreon
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Request describes a single attempt to call Patreon API as seen by middleware.
+type Request struct {
+	// HTTP is the request about to be sent. Middleware may add headers or replace it with a copy
+	// (e.g. with HTTP.WithContext to attach tracing data).
+	HTTP *http.Request
+	// Path is the API endpoint path without base URL and query, e.g. "/oauth2/api/current_user".
+	Path string
+	// Include lists related resources requested with WithIncludes.
+	Include []string
+	// Fields lists resource attributes requested with WithFields, keyed by resource type.
+	Fields map[string][]string
+	// PageSize is the page size requested with WithPageSize, zero if not set.
+	PageSize int
+	// Cursor is the pagination cursor requested with WithCursor, empty if not set.
+	Cursor string
+	// Attempt is the attempt number, starting from 1 (see RetryPolicy).
+	Attempt int
+}
+
+// Response describes the result of a single attempt to call Patreon API.
+type Response struct {
+	// StatusCode is HTTP status code, zero if no response was received.
+	StatusCode int
+	// Header contains response headers, nil if no response was received.
+	Header http.Header
+	// Latency is the time spent sending the request and reading the response.
+	Latency time.Duration
+}
+
+// Doer sends a single API request. It returns the error decoded from the response
+// (usually *APIError) along with the response itself, so middleware can inspect both.
+type Doer interface {
+	Do(req *Request) (*Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *Request) (*Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *Request) (*Response, error) {
+	return f(req)
+}
+
+// Middleware wraps Doer to observe or alter API requests, e.g. for logging, tracing or metrics.
+type Middleware func(next Doer) Doer
+
+// Use appends middleware to the chain invoked for every attempt of every API call.
+// Middleware added first is the outermost one. Use must not be called concurrently with API calls.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// chain wraps doer with client's middleware.
+func (c *Client) chain(doer Doer) Doer {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		doer = c.middleware[i](doer)
+	}
+
+	return doer
+}
+
+func newRequest(req *http.Request, path string, cfg options, attempt int) *Request {
+	r := &Request{
+		HTTP:     req,
+		Path:     path,
+		PageSize: cfg.size,
+		Cursor:   cfg.cursor,
+		Attempt:  attempt,
+	}
+
+	if cfg.include != "" {
+		r.Include = strings.Split(cfg.include, ",")
+	}
+
+	if len(cfg.fields) > 0 {
+		r.Fields = make(map[string][]string, len(cfg.fields))
+		for resource, fields := range cfg.fields {
+			r.Fields[resource] = strings.Split(fields, ",")
+		}
+	}
+
+	return r
+}
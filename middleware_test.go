@@ -0,0 +1,121 @@
+package patreon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/campaigns/123/pledges", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "trace-1", request.Header.Get("X-Trace-Id"))
+
+		attempts++
+		if attempts == 1 {
+			writer.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(writer, throttledResp)
+			return
+		}
+
+		fmt.Fprint(writer, fetchPledgesResp)
+	})
+
+	var (
+		calls     []string
+		requests  []*Request
+		responses []*Response
+		errs      []error
+	)
+
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			calls = append(calls, "outer")
+			resp, err := next.Do(req)
+			requests = append(requests, req)
+			responses = append(responses, resp)
+			errs = append(errs, err)
+			return resp, err
+		})
+	}, func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			calls = append(calls, "inner")
+			req.HTTP.Header.Set("X-Trace-Id", "trace-1")
+			return next.Do(req)
+		})
+	})
+
+	_, err := client.FetchPledges("123",
+		WithIncludes("patron", "reward"),
+		WithFields("pledge", "amount_cents", "created_at"),
+		WithPageSize(10))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"outer", "inner", "outer", "inner"}, calls)
+	require.Len(t, requests, 2)
+
+	for i, req := range requests {
+		require.Equal(t, i+1, req.Attempt)
+		require.Equal(t, "/oauth2/api/campaigns/123/pledges", req.Path)
+		require.Equal(t, "/oauth2/api/campaigns/123/pledges", req.HTTP.URL.Path)
+		require.Equal(t, []string{"patron", "reward"}, req.Include)
+		require.Equal(t, map[string][]string{"pledge": {"amount_cents", "created_at"}}, req.Fields)
+		require.Equal(t, 10, req.PageSize)
+		require.Empty(t, req.Cursor)
+	}
+
+	require.Equal(t, http.StatusTooManyRequests, responses[0].StatusCode)
+	require.True(t, errors.Is(errs[0], ErrRateLimited))
+	require.True(t, responses[0].Latency > 0)
+
+	require.Equal(t, http.StatusOK, responses[1].StatusCode)
+	require.NoError(t, errs[1])
+	require.True(t, responses[1].Latency > 0)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		t.Fatal("request must not be sent")
+	})
+
+	blocked := errors.New("blocked")
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			return nil, blocked
+		})
+	})
+
+	_, err := client.FetchUser()
+	require.Equal(t, blocked, err)
+}
+
+func TestMiddlewareShortCircuitNotRetried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	calls := 0
+	blocked := errors.New("circuit is open")
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			calls++
+			return nil, blocked
+		})
+	})
+
+	_, err := client.FetchUser()
+	require.Equal(t, blocked, err)
+	require.Equal(t, 1, calls)
+}
@@ -1,11 +1,23 @@
 package patreon
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 )
 
+// timeLayouts lists formats accepted by NullTime.Scan for string values,
+// as drivers like SQLite store timestamps as text.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
 // NullTime represents a time.Time that may be JSON "null".
 // golang prior 1.8 doesn't support this scenario (fails with error: parsing time "null" as ""2006-01-02T15:04:05Z07:00"": cannot parse "null" as """)
 type NullTime struct {
@@ -26,3 +38,75 @@ func (t *NullTime) UnmarshalJSON(data []byte) error {
 
 	return err
 }
+
+// MarshalJSON implements json.Marshaler, invalid time is encoded as JSON "null"
+func (t NullTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+
+	return t.Time.MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler, invalid time is encoded as empty string
+func (t NullTime) MarshalText() ([]byte, error) {
+	if !t.Valid {
+		return []byte{}, nil
+	}
+
+	return t.Time.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, empty string and "null" are decoded as invalid time
+func (t *NullTime) UnmarshalText(data []byte) error {
+	s := string(data)
+	if s == "" || strings.EqualFold(s, "null") {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+
+	err := t.Time.UnmarshalText(data)
+	t.Valid = err == nil
+
+	return err
+}
+
+// Scan implements sql.Scanner, so NullTime can be read from database/sql rows.
+// Accepts time.Time, nil and text values in RFC 3339 or SQL timestamp formats.
+func (t *NullTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time, t.Valid = v, true
+		return nil
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	}
+
+	return fmt.Errorf("cannot scan %T into NullTime", value)
+}
+
+// Value implements driver.Valuer, invalid time is stored as NULL.
+func (t NullTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+
+	return t.Time, nil
+}
+
+func (t *NullTime) parse(s string) error {
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time, t.Valid = parsed, true
+			return nil
+		}
+	}
+
+	t.Time, t.Valid = time.Time{}, false
+	return fmt.Errorf("cannot parse time '%s'", s)
+}
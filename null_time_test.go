@@ -28,3 +28,78 @@ func TestNullTime_parseInvalidTime(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, s.Time.Valid)
 }
+
+func TestNullTime_marshal(t *testing.T) {
+	s := struct {
+		Valid   NullTime `json:"valid"`
+		Invalid NullTime `json:"invalid"`
+	}{
+		Valid: NullTime{Time: time.Date(2017, 6, 20, 23, 21, 34, 0, time.UTC), Valid: true},
+	}
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, `{ "valid": "2017-06-20T23:21:34Z", "invalid": null }`, string(data))
+}
+
+func TestNullTime_scan(t *testing.T) {
+	expected := time.Date(2017, 6, 20, 23, 21, 34, 514822000, time.UTC)
+
+	values := []interface{}{
+		expected,
+		"2017-06-20T23:21:34.514822Z",
+		[]byte("2017-06-20 23:21:34.514822"),
+		"2017-06-20 23:21:34.514822+00:00",
+	}
+
+	for _, value := range values {
+		nt := NullTime{}
+		require.NoError(t, nt.Scan(value))
+		require.True(t, nt.Valid)
+		require.True(t, expected.Equal(nt.Time), "%v", value)
+	}
+
+	nt := NullTime{Time: expected, Valid: true}
+	require.NoError(t, nt.Scan(nil))
+	require.False(t, nt.Valid)
+	require.True(t, nt.Time.IsZero())
+
+	require.Error(t, nt.Scan(42))
+	require.Error(t, nt.Scan("yesterday"))
+	require.False(t, nt.Valid)
+}
+
+func TestNullTime_value(t *testing.T) {
+	expected := time.Date(2017, 6, 20, 23, 21, 34, 0, time.UTC)
+
+	value, err := NullTime{Time: expected, Valid: true}.Value()
+	require.NoError(t, err)
+	require.Equal(t, expected, value)
+
+	value, err = NullTime{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestNullTime_text(t *testing.T) {
+	nt := NullTime{Time: time.Date(2017, 6, 20, 23, 21, 34, 0, time.UTC), Valid: true}
+
+	text, err := nt.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "2017-06-20T23:21:34Z", string(text))
+
+	actual := NullTime{}
+	require.NoError(t, actual.UnmarshalText(text))
+	require.True(t, actual.Valid)
+	require.True(t, nt.Time.Equal(actual.Time))
+
+	text, err = NullTime{}.MarshalText()
+	require.NoError(t, err)
+	require.Empty(t, text)
+
+	require.NoError(t, actual.UnmarshalText(text))
+	require.False(t, actual.Valid)
+
+	require.Error(t, actual.UnmarshalText([]byte("yesterday")))
+	require.False(t, actual.Valid)
+}
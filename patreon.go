@@ -1,11 +1,16 @@
 package patreon
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -20,24 +25,109 @@ const (
 
 const (
 	baseURL = "https://api.patreon.com"
+
+	// defaultMaxResponseSize limits the size of API responses, see WithMaxResponseSize
+	defaultMaxResponseSize = 16 << 20
+
+	// maxDrainSize limits the number of unread bytes to discard before closing response body
+	maxDrainSize = 64 << 10
 )
 
 // Client manages communication with Patreon API.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	retry      RetryPolicy
+	limiter    RateLimiter
+	strict     bool
+	types      map[string]func() interface{}
+
+	maxResponseSize int64
+	keepSource      bool
+	userAgent       string
+	header          http.Header
+	middleware      []Middleware
 }
 
+type clientOption func(*Client)
+
 // NewClient returns a new Patreon API client. If a nil httpClient is
 // provided, http.DefaultClient will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient *http.Client) *Client {
+func NewClient(httpClient *http.Client, opts ...clientOption) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	return &Client{httpClient: httpClient, baseURL: baseURL}
+	c := &Client{httpClient: httpClient, baseURL: baseURL, maxResponseSize: defaultMaxResponseSize}
+	for _, fn := range opts {
+		fn(c)
+	}
+
+	return c
+}
+
+// WithBaseURL overrides Patreon API address (https://api.patreon.com by default),
+// for instance to point the client to a proxy or a local stand-in server.
+func WithBaseURL(addr string) clientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(addr, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, same as passing it to NewClient.
+// If a nil httpClient is provided, http.DefaultClient will be used.
+func WithHTTPClient(httpClient *http.Client) clientOption {
+	return func(c *Client) {
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with every request.
+func WithUserAgent(userAgent string) clientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request. It may be used multiple times to add several values.
+func WithHeader(key, value string) clientOption {
+	return func(c *Client) {
+		if c.header == nil {
+			c.header = http.Header{}
+		}
+
+		c.header.Add(key, value)
+	}
+}
+
+// WithStrictIncludes makes the client fail on included resources of unknown types instead of
+// decoding them into RawResource (see Includes.Strict). This is useful in tests to detect API changes.
+func WithStrictIncludes() clientOption {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// WithMaxResponseSize limits the size of API responses the client reads into memory (16MB by default).
+// Larger responses fail with an error.
+func WithMaxResponseSize(size int64) clientOption {
+	return func(c *Client) {
+		c.maxResponseSize = size
+	}
+}
+
+// WithSourceDocuments makes the client keep the JSON document each response was decoded from,
+// so MarshalDocument can reproduce it exactly. It's disabled by default, as it doubles memory used by responses.
+func WithSourceDocuments() clientOption {
+	return func(c *Client) {
+		c.keepSource = true
+	}
 }
 
 // Client returns the HTTP client configured for this client.
@@ -49,8 +139,27 @@ func (c *Client) Client() *http.Client {
 // This API returns a representation of the user who granted your OAuth client the provided access_token.
 // It is most typically used in the OAuth "Log in with Patreon" flow to create or update the user's account on your site.
 func (c *Client) FetchUser(opts ...requestOption) (*UserResponse, error) {
+	return c.FetchUserContext(context.Background(), opts...)
+}
+
+// FetchUserContext is like FetchUser, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchUserContext(ctx context.Context, opts ...requestOption) (*UserResponse, error) {
 	resp := &UserResponse{}
-	err := c.get("/oauth2/api/current_user", resp, opts...)
+	err := c.get(ctx, "/oauth2/api/current_user", resp, opts...)
+	return resp, err
+}
+
+// FetchIdentity fetches the user who granted your OAuth client the provided access_token using API v2.
+// Unlike API v1, no attributes are returned unless requested explicitly, use WithFields("user", ...) to select them
+// and WithIncludes(IdentityDefaultRelations) to fetch user's memberships and campaign.
+func (c *Client) FetchIdentity(opts ...requestOption) (*UserResponse, error) {
+	return c.FetchIdentityContext(context.Background(), opts...)
+}
+
+// FetchIdentityContext is like FetchIdentity, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchIdentityContext(ctx context.Context, opts ...requestOption) (*UserResponse, error) {
+	resp := &UserResponse{}
+	err := c.get(ctx, "/api/oauth2/v2/identity", resp, opts...)
 	return resp, err
 }
 
@@ -59,8 +168,40 @@ func (c *Client) FetchUser(opts ...requestOption) (*UserResponse, error) {
 // If there are more than twenty pledges to the campaign, the first twenty will be returned, along with a link to the
 // next page of pledges.
 func (c *Client) FetchCampaign(opts ...requestOption) (*CampaignResponse, error) {
+	return c.FetchCampaignContext(context.Background(), opts...)
+}
+
+// FetchCampaignContext is like FetchCampaign, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchCampaignContext(ctx context.Context, opts ...requestOption) (*CampaignResponse, error) {
 	resp := &CampaignResponse{}
-	err := c.get("/oauth2/api/current_user/campaigns", resp, opts...)
+	err := c.get(ctx, "/oauth2/api/current_user/campaigns", resp, opts...)
+	return resp, err
+}
+
+// FetchCampaigns fetches the campaigns owned by the authorized user using API v2.
+// Use WithFields("campaign", ...) to select campaign attributes and WithIncludes to fetch related resources.
+func (c *Client) FetchCampaigns(opts ...requestOption) (*CampaignResponse, error) {
+	return c.FetchCampaignsContext(context.Background(), opts...)
+}
+
+// FetchCampaignsContext is like FetchCampaigns, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchCampaignsContext(ctx context.Context, opts ...requestOption) (*CampaignResponse, error) {
+	resp := &CampaignResponse{}
+	err := c.get(ctx, "/api/oauth2/v2/campaigns", resp, opts...)
+	return resp, err
+}
+
+// FetchCampaignByID fetches a particular campaign by ID using API v2.
+// Use WithIncludes(CampaignV2DefaultRelations) to fetch campaign's tiers, creator, benefits and goals.
+func (c *Client) FetchCampaignByID(campaignId string, opts ...requestOption) (*CampaignByIDResponse, error) {
+	return c.FetchCampaignByIDContext(context.Background(), campaignId, opts...)
+}
+
+// FetchCampaignByIDContext is like FetchCampaignByID, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchCampaignByIDContext(ctx context.Context, campaignId string, opts ...requestOption) (*CampaignByIDResponse, error) {
+	resp := &CampaignByIDResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/campaigns/%s", campaignId)
+	err := c.get(ctx, path, resp, opts...)
 	return resp, err
 }
 
@@ -69,12 +210,144 @@ func (c *Client) FetchCampaign(opts ...requestOption) (*CampaignResponse, error)
 // and provide relationship references to the users who made each respective pledge. The API response will also contain
 // a links section which may be used to fetch the next page of pledges, or go back to the first page.
 func (c *Client) FetchPledges(campaignId string, opts ...requestOption) (*PledgeResponse, error) {
+	return c.FetchPledgesContext(context.Background(), campaignId, opts...)
+}
+
+// FetchPledgesContext is like FetchPledges, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchPledgesContext(ctx context.Context, campaignId string, opts ...requestOption) (*PledgeResponse, error) {
 	resp := &PledgeResponse{}
 	path := fmt.Sprintf("/oauth2/api/campaigns/%s/pledges", campaignId)
-	err := c.get(path, resp, opts...)
+	err := c.get(ctx, path, resp, opts...)
+	return resp, err
+}
+
+// FetchMembers fetches the members of the given campaign using API v2.
+// Members supersede API v1 pledges. Use WithFields("member", ...) to select member attributes,
+// WithIncludes(MemberDefaultRelations) to fetch related users, tiers and addresses, and WithPageSize and WithCursor
+// (with Links.Next or Meta.Pagination.Cursors.Next of the previous response) to paginate.
+func (c *Client) FetchMembers(campaignId string, opts ...requestOption) (*MembersResponse, error) {
+	return c.FetchMembersContext(context.Background(), campaignId, opts...)
+}
+
+// FetchMembersContext is like FetchMembers, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchMembersContext(ctx context.Context, campaignId string, opts ...requestOption) (*MembersResponse, error) {
+	resp := &MembersResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/campaigns/%s/members", campaignId)
+	err := c.get(ctx, path, resp, opts...)
+	return resp, err
+}
+
+// FetchMember fetches a particular member by ID using API v2.
+// Use WithIncludes to fetch related resources: user, address, campaign, currently_entitled_tiers and pledge_history.
+func (c *Client) FetchMember(memberId string, opts ...requestOption) (*MemberResponse, error) {
+	return c.FetchMemberContext(context.Background(), memberId, opts...)
+}
+
+// FetchMemberContext is like FetchMember, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchMemberContext(ctx context.Context, memberId string, opts ...requestOption) (*MemberResponse, error) {
+	resp := &MemberResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/members/%s", memberId)
+	err := c.get(ctx, path, resp, opts...)
+	return resp, err
+}
+
+// FetchPosts fetches the posts of the given campaign using API v2.
+// Use WithFields("post", ...) to select post attributes, and WithPageSize and WithCursor
+// (with Links.Next or Meta.Pagination.Cursors.Next of the previous response) to paginate.
+func (c *Client) FetchPosts(campaignId string, opts ...requestOption) (*PostsResponse, error) {
+	return c.FetchPostsContext(context.Background(), campaignId, opts...)
+}
+
+// FetchPostsContext is like FetchPosts, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchPostsContext(ctx context.Context, campaignId string, opts ...requestOption) (*PostsResponse, error) {
+	resp := &PostsResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/campaigns/%s/posts", campaignId)
+	err := c.get(ctx, path, resp, opts...)
+	return resp, err
+}
+
+// FetchPost fetches a particular post by ID using API v2.
+func (c *Client) FetchPost(postId string, opts ...requestOption) (*PostResponse, error) {
+	return c.FetchPostContext(context.Background(), postId, opts...)
+}
+
+// FetchPostContext is like FetchPost, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) FetchPostContext(ctx context.Context, postId string, opts ...requestOption) (*PostResponse, error) {
+	resp := &PostResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/posts/%s", postId)
+	err := c.get(ctx, path, resp, opts...)
+	return resp, err
+}
+
+// CreateWebhook registers a new webhook for the given campaign using API v2.
+// The returned webhook contains the secret to verify messages with VerifySignature.
+func (c *Client) CreateWebhook(campaignId string, params WebhookParams, opts ...requestOption) (*WebhookResponse, error) {
+	return c.CreateWebhookContext(context.Background(), campaignId, params, opts...)
+}
+
+// CreateWebhookContext is like CreateWebhook, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) CreateWebhookContext(ctx context.Context, campaignId string, params WebhookParams, opts ...requestOption) (*WebhookResponse, error) {
+	doc := webhookDocument{}
+	doc.Data.Type = "webhook"
+	doc.Data.Attributes = params
+	doc.Data.Relationships = &webhookRelationships{}
+	doc.Data.Relationships.Campaign.Data = Data{ID: campaignId, Type: "campaign"}
+
+	resp := &WebhookResponse{}
+	err := c.do(ctx, http.MethodPost, "/api/oauth2/v2/webhooks", doc, resp, opts...)
+	return resp, err
+}
+
+// ListWebhooks fetches the webhooks created by the current OAuth client using API v2.
+func (c *Client) ListWebhooks(opts ...requestOption) (*WebhooksResponse, error) {
+	return c.ListWebhooksContext(context.Background(), opts...)
+}
+
+// ListWebhooksContext is like ListWebhooks, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) ListWebhooksContext(ctx context.Context, opts ...requestOption) (*WebhooksResponse, error) {
+	resp := &WebhooksResponse{}
+	err := c.get(ctx, "/api/oauth2/v2/webhooks", resp, opts...)
 	return resp, err
 }
 
+// UpdateWebhook updates the given webhook using API v2. Only non-empty params are changed.
+// Set params.Paused to false to resume a webhook paused after failed deliveries.
+func (c *Client) UpdateWebhook(webhookId string, params WebhookParams, opts ...requestOption) (*WebhookResponse, error) {
+	return c.UpdateWebhookContext(context.Background(), webhookId, params, opts...)
+}
+
+// UpdateWebhookContext is like UpdateWebhook, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) UpdateWebhookContext(ctx context.Context, webhookId string, params WebhookParams, opts ...requestOption) (*WebhookResponse, error) {
+	doc := webhookDocument{}
+	doc.Data.Type = "webhook"
+	doc.Data.ID = webhookId
+	doc.Data.Attributes = params
+
+	resp := &WebhookResponse{}
+	path := fmt.Sprintf("/api/oauth2/v2/webhooks/%s", webhookId)
+	err := c.do(ctx, http.MethodPatch, path, doc, resp, opts...)
+	return resp, err
+}
+
+// DeleteWebhook deletes the given webhook using API v2.
+func (c *Client) DeleteWebhook(webhookId string) error {
+	return c.DeleteWebhookContext(context.Background(), webhookId)
+}
+
+// DeleteWebhookContext is like DeleteWebhook, but uses ctx to control cancellation and deadline of the request.
+func (c *Client) DeleteWebhookContext(ctx context.Context, webhookId string) error {
+	path := fmt.Sprintf("/api/oauth2/v2/webhooks/%s", webhookId)
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// prepareIncludes applies client settings to the 'Included' field of the response v before decoding.
+func (c *Client) prepareIncludes(v interface{}) {
+	if includes, ok := includesOf(v); ok {
+		includes.Strict = c.strict
+		includes.types = c.types
+	}
+}
+
 func (c *Client) buildURL(path string, opts ...requestOption) (string, error) {
 	cfg := getOptions(opts...)
 
@@ -107,25 +380,181 @@ func (c *Client) buildURL(path string, opts ...requestOption) (string, error) {
 	return u.String(), nil
 }
 
-func (c *Client) get(path string, v interface{}, opts ...requestOption) error {
+func (c *Client) get(ctx context.Context, path string, v interface{}, opts ...requestOption) error {
+	return c.do(ctx, http.MethodGet, path, nil, v, opts...)
+}
+
+// do sends a request with JSON encoded body (if not nil) and decodes the response into v (if not nil).
+// Only GET requests are retried, as other methods are not guaranteed to be idempotent.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, v interface{}, opts ...requestOption) error {
 	addr, err := c.buildURL(path, opts...)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.httpClient.Get(addr)
+	var payload []byte
+	if body != nil {
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	// wait keeps the delay suggested by the server for the last attempt
+	var wait time.Duration
+	doer := c.chain(DoerFunc(func(r *Request) (resp *Response, err error) {
+		resp, wait, err = c.send(r.HTTP, v)
+		return resp, err
+	}))
+
+	cfg := getOptions(opts...)
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, addr, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		for key, values := range c.header {
+			req.Header[key] = append([]string(nil), values...)
+		}
+
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		wait = 0
+		resp, err := doer.Do(newRequest(req, path, cfg, attempt))
+		if err == nil {
+			return nil
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+
+		if status == http.StatusTooManyRequests && c.limiter != nil {
+			c.limiter.Throttled(wait)
+		}
+
+		if method != http.MethodGet || attempt >= c.retry.MaxAttempts || !shouldRetry(ctx, status, err) {
+			return err
+		}
+
+		if err := sleep(ctx, c.retry.delay(attempt, wait)); err != nil {
+			return err
+		}
+	}
+}
+
+// send sends a single request and decodes the response into v.
+// It returns the response (with zero status code if no response was received) and the delay suggested by the server, if any.
+func (c *Client) send(req *http.Request, v interface{}) (*Response, time.Duration, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		result := &Response{Latency: time.Since(start)}
+
+		// http.Client wraps context errors into *url.Error, return them as is,
+		// so callers can compare against context.Canceled and context.DeadlineExceeded
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, 0, ctxErr
+		}
+
+		return result, 0, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	defer drainAndClose(resp.Body)
+
+	body, err := c.readBody(resp.Body)
+
+	result := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Latency:    time.Since(start),
+	}
+
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, 0, ctxErr
+		}
+
+		return result, 0, fmt.Errorf("failed to read response from %s: %w", req.URL.Path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       body,
+		}
+
+		// Error body is not guaranteed to be JSON (HTML page from a proxy, empty 502, etc)
 		errs := ErrorResponse{}
-		if err := json.NewDecoder(resp.Body).Decode(&errs); err != nil {
-			return err
+		if err := json.Unmarshal(body, &errs); err == nil {
+			apiErr.Errors = errs.Errors
+		}
+
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait == 0 {
+			wait = errs.retryAfter()
 		}
 
-		return errs
+		return result, wait, apiErr
 	}
 
-	return json.NewDecoder(resp.Body).Decode(v)
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return result, 0, nil
+	}
+
+	c.prepareIncludes(v)
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return result, 0, fmt.Errorf("failed to decode response from %s: %w", req.URL.Path, err)
+	}
+
+	if keeper, ok := v.(sourceKeeper); ok && c.keepSource {
+		keeper.setSource(body)
+	}
+
+	return result, 0, nil
+}
+
+// readBody reads the response body up to the configured limit.
+func (c *Client) readBody(r io.Reader) ([]byte, error) {
+	limit := c.maxResponseSize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("response body exceeds %d bytes", limit)
+	}
+
+	return body, nil
+}
+
+// drainAndClose reads the rest of the body, so the underlying connection can be reused, and closes it.
+// Bodies larger than maxDrainSize are not drained, as it's cheaper to open a new connection.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainSize))
+	body.Close()
 }
@@ -1,9 +1,13 @@
 package patreon
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -18,8 +22,7 @@ var (
 func setup() {
 	mux = http.NewServeMux()
 	server = httptest.NewServer(mux)
-	client = NewClient(nil)
-	client.baseURL = server.URL
+	client = NewClient(nil, WithBaseURL(server.URL))
 }
 
 func teardown() {
@@ -53,3 +56,138 @@ func TestClient(t *testing.T) {
 	client := NewClient(tc)
 	require.Equal(t, tc, client.Client())
 }
+
+func TestClientOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hc := &http.Client{}
+	client = NewClient(nil,
+		WithHTTPClient(hc),
+		WithBaseURL(server.URL+"/"),
+		WithUserAgent("podsync/1.0"),
+		WithHeader("X-Request-Source", "test"),
+		WithHeader("X-Request-Source", "unit"))
+
+	require.Equal(t, hc, client.Client())
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "podsync/1.0", request.UserAgent())
+		require.Equal(t, []string{"test", "unit"}, request.Header.Values("X-Request-Source"))
+		fmt.Fprint(writer, currentUserResp)
+	})
+
+	_, err := client.FetchUser()
+	require.NoError(t, err)
+}
+
+func TestFetchUserContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		cancel()
+		<-request.Context().Done()
+	})
+
+	_, err := client.FetchUserContext(ctx)
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestFetchPledgesContextDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	mux.HandleFunc("/oauth2/api/campaigns/123/pledges", func(writer http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	})
+
+	_, err := client.FetchPledgesContext(ctx, "123")
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+type closeTracker struct {
+	io.ReadCloser
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return c.ReadCloser.Close()
+}
+
+type trackingTransport struct {
+	bodies []*closeTracker
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &closeTracker{ReadCloser: resp.Body}
+	t.bodies = append(t.bodies, body)
+	resp.Body = body
+	return resp, nil
+}
+
+func TestResponseBodyClosed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	transport := &trackingTransport{}
+	client.httpClient = &http.Client{Transport: transport}
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, currentUserResp)
+	})
+
+	mux.HandleFunc("/oauth2/api/current_user/campaigns", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(writer, "not found")
+	})
+
+	_, err := client.FetchUser()
+	require.NoError(t, err)
+
+	_, err = client.FetchCampaign()
+	require.Error(t, err)
+
+	require.Len(t, transport.bodies, 2)
+	for _, body := range transport.bodies {
+		require.True(t, body.closed)
+	}
+}
+
+func TestResponseTooLarge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithMaxResponseSize(64))
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, currentUserResp)
+	})
+
+	_, err := client.FetchUser()
+	require.EqualError(t, err, "failed to read response from /oauth2/api/current_user: response body exceeds 64 bytes")
+}
+
+func TestResponseTruncated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, currentUserResp[:100])
+	})
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode response from /oauth2/api/current_user")
+}
@@ -0,0 +1,192 @@
+package patreontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mxpv/patreon-go"
+)
+
+// resource is a generic JSON:API resource object.
+type resource struct {
+	Type          string                     `json:"type"`
+	ID            string                     `json:"id"`
+	Attributes    map[string]json.RawMessage `json:"attributes"`
+	Relationships map[string]json.RawMessage `json:"relationships,omitempty"`
+}
+
+// newResource converts a typed resource (such as *patreon.User) into a generic one.
+// Null relationships are dropped.
+func newResource(v interface{}) (*resource, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &resource{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+
+	for name, rel := range res.Relationships {
+		if string(rel) == "null" {
+			delete(res.Relationships, name)
+		}
+	}
+
+	if res.Attributes == nil {
+		res.Attributes = map[string]json.RawMessage{}
+	}
+
+	return res, nil
+}
+
+// related returns resource identifiers referenced by the relationship.
+func (r *resource) related(name string) []patreon.Data {
+	raw, ok := r.Relationships[name]
+	if !ok {
+		return nil
+	}
+
+	rel := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(raw, &rel); err != nil {
+		return nil
+	}
+
+	var many []patreon.Data
+	if err := json.Unmarshal(rel.Data, &many); err == nil {
+		return many
+	}
+
+	var one patreon.Data
+	if err := json.Unmarshal(rel.Data, &one); err == nil && one.ID != "" {
+		return []patreon.Data{one}
+	}
+
+	return nil
+}
+
+// document builds a JSON:API response honoring include and fields[...] query parameters.
+type document struct {
+	Data     interface{}       `json:"data"`
+	Included []*resource       `json:"included,omitempty"`
+	Links    map[string]string `json:"links,omitempty"`
+	Meta     interface{}       `json:"meta,omitempty"`
+
+	server  *Server
+	include [][]string
+	fields  map[string]map[string]bool
+	v2      bool
+	seen    map[patreon.Data]bool
+}
+
+func (s *Server) newDocument(r *http.Request, v2 bool) *document {
+	doc := &document{
+		server: s,
+		fields: make(map[string]map[string]bool),
+		v2:     v2,
+		seen:   make(map[patreon.Data]bool),
+	}
+
+	q := r.URL.Query()
+
+	if include := q.Get("include"); include != "" {
+		for _, path := range strings.Split(include, ",") {
+			doc.include = append(doc.include, strings.Split(path, "."))
+		}
+	}
+
+	for key := range q {
+		if !strings.HasPrefix(key, "fields[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		typ := key[len("fields[") : len(key)-1]
+		doc.fields[typ] = make(map[string]bool)
+		for _, field := range strings.Split(q.Get(key), ",") {
+			doc.fields[typ][field] = true
+		}
+	}
+
+	return doc
+}
+
+// add renders a primary resource and includes its related resources requested with include parameter.
+func (d *document) add(v interface{}) *resource {
+	res, err := newResource(v)
+	if err != nil {
+		panic(err)
+	}
+
+	d.seen[patreon.Data{ID: res.ID, Type: res.Type}] = true
+
+	// Primary data shouldn't be duplicated in included resources
+	d.Included = removeResource(d.Included, res)
+
+	for _, path := range d.include {
+		d.addIncluded(res, path)
+	}
+
+	return d.filter(res)
+}
+
+func (d *document) addIncluded(res *resource, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	for _, data := range res.related(path[0]) {
+		v, ok := d.server.resources[data]
+		if !ok {
+			continue
+		}
+
+		related, err := newResource(v)
+		if err != nil {
+			panic(err)
+		}
+
+		if !d.seen[data] {
+			d.seen[data] = true
+			d.Included = append(d.Included, d.filter(related))
+		}
+
+		d.addIncluded(related, path[1:])
+	}
+}
+
+// filter applies sparse fieldsets to resource attributes.
+// API v2 doesn't return any attributes unless they are explicitly requested.
+func (d *document) filter(res *resource) *resource {
+	fields, ok := d.fields[res.Type]
+	if !ok && !d.v2 {
+		return res
+	}
+
+	for name := range res.Attributes {
+		if !fields[name] {
+			delete(res.Attributes, name)
+		}
+	}
+
+	return res
+}
+
+func (d *document) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	_ = json.NewEncoder(w).Encode(d)
+}
+
+func removeResource(list []*resource, res *resource) []*resource {
+	for i, item := range list {
+		if item.Type == res.Type && item.ID == res.ID {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,349 @@
+package patreontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RecorderMode specifies whether Recorder records or replays interactions.
+type RecorderMode int
+
+const (
+	// Replay serves responses from the cassette file without hitting the network.
+	Replay RecorderMode = iota
+
+	// Record sends requests using the underlying transport and records interactions to be saved to the cassette file.
+	Record
+)
+
+// Redacted replaces sensitive values in recorded interactions.
+const Redacted = "REDACTED"
+
+// redactedEmail replaces email addresses in recorded interactions, so they remain valid emails.
+const redactedEmail = "redacted@example.com"
+
+// Interaction is a recorded request and the response to it.
+type Interaction struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	} `json:"response"`
+}
+
+// Cassette is a list of recorded interactions, stored as a JSON file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper which records interactions to a cassette file and replays them in tests.
+// Authorization headers, emails, addresses, card tokens and webhook secrets are redacted before recording.
+//
+//	rec, err := patreontest.NewRecorder("testdata/campaign.json", patreontest.Replay)
+//	...
+//	client := patreon.NewClient(rec.Client())
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	transport http.RoundTripper
+	strict    bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+type recorderOption func(*Recorder)
+
+// WithTransport sets the transport used to send requests in Record mode and unmatched requests in non-strict
+// Replay mode. http.DefaultTransport is used by default.
+func WithTransport(transport http.RoundTripper) recorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithStrictReplay makes Recorder fail requests which don't match any unused interaction in Replay mode.
+// By default, matching interactions are replayed again and unmatched requests are sent over the network.
+func WithStrictReplay() recorderOption {
+	return func(r *Recorder) {
+		r.strict = true
+	}
+}
+
+// NewRecorder creates a new recorder. In Replay mode the cassette file is loaded and must exist.
+// In Record mode interactions are kept in memory until Save is called.
+func NewRecorder(path string, mode RecorderMode, opts ...recorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+	}
+
+	for _, fn := range opts {
+		fn(r)
+	}
+
+	if mode == Replay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to load cassette %s: %w", path, err)
+		}
+
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client which uses the recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes recorded interactions to the cassette file. It does nothing in Replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, out, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == Record {
+		return r.record(out, body)
+	}
+
+	if i := r.match(req, body); i != nil {
+		return i.response(req), nil
+	}
+
+	if r.strict {
+		return nil, fmt.Errorf("patreontest: no recorded interaction for %s %s", req.Method, req.URL)
+	}
+
+	return r.transport.RoundTrip(out)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Interaction{}
+	i.Request.Method = req.Method
+	i.Request.URL = req.URL.String()
+	i.Request.Header = redactHeader(req.Header)
+	i.Request.Body = redactBody(body)
+	i.Response.StatusCode = resp.StatusCode
+	i.Response.Header = redactHeader(resp.Header)
+	i.Response.Body = redactBody(respBody)
+
+	// Redacted body may differ in length, the replayed response gets its length from the recorded body
+	i.Response.Header.Del("Content-Length")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	// Callers get the original response, only the recording is redacted
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// match returns the first unused interaction matching request method, URL and body.
+// In non-strict mode, already used interactions are replayed again if there are no unused ones.
+func (r *Recorder) match(req *http.Request, body []byte) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := req.URL.String()
+	redacted := redactBody(body)
+
+	var reused *Interaction
+	for n, i := range r.cassette.Interactions {
+		if i.Request.Method != req.Method || i.Request.URL != url || i.Request.Body != redacted {
+			continue
+		}
+
+		if !r.used[n] {
+			r.used[n] = true
+			return i
+		}
+
+		if reused == nil && !r.strict {
+			reused = i
+		}
+	}
+
+	return reused
+}
+
+func (i *Interaction) response(req *http.Request) *http.Response {
+	header := i.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(i.Response.StatusCode) + " " + http.StatusText(i.Response.StatusCode),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(i.Response.Body))),
+		ContentLength: int64(len(i.Response.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads request body and returns it along with a copy of the request to forward to the transport,
+// as RoundTripper must not modify the original request.
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	return body, out, nil
+}
+
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range sensitiveHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, Redacted)
+		}
+	}
+
+	return header
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// sensitiveAttributes lists attributes to redact by resource type.
+var sensitiveAttributes = map[string][]string{
+	"address": {"addressee", "city", "country", "line_1", "line_2", "phone_number", "postal_code", "state"},
+	"card":    {"number", "payment_token", "payment_token_id"},
+	"webhook": {"secret"},
+}
+
+// sensitiveIDs lists resource types which IDs are redacted, such as cards identified by payment tokens.
+var sensitiveIDs = map[string]bool{
+	"card": true,
+}
+
+// redactBody removes personal data from JSON:API documents. Emails are replaced in non-JSON bodies as well.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	// Keep numbers and HTML in post content as sent by Patreon
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return emailPattern.ReplaceAllString(string(body), redactedEmail)
+	}
+
+	doc = redactValue(doc)
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return emailPattern.ReplaceAllString(string(body), redactedEmail)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return emailPattern.ReplaceAllString(v, redactedEmail)
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	case map[string]interface{}:
+		typ, _ := v["type"].(string)
+		if _, ok := v["id"].(string); ok && sensitiveIDs[typ] {
+			v["id"] = Redacted
+		}
+
+		if attrs, ok := v["attributes"].(map[string]interface{}); ok {
+			for _, name := range sensitiveAttributes[typ] {
+				switch attrs[name].(type) {
+				case string:
+					attrs[name] = Redacted
+				case json.Number:
+					attrs[name] = json.Number("0")
+				}
+			}
+		}
+
+		for key, value := range v {
+			v[key] = redactValue(value)
+		}
+	}
+
+	return v
+}
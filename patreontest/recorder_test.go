@@ -0,0 +1,137 @@
+package patreontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mxpv/patreon-go"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	s := NewServer()
+	seed(s)
+
+	address := &patreon.Address{Type: "address", ID: "300"}
+	address.Attributes.Line1 = "1 Main St"
+	address.Attributes.City = "Springfield"
+	s.AddResource(address)
+
+	pledge := &patreon.Pledge{ID: "p5"}
+	pledge.Relationships.Address = &patreon.AddressRelationship{Data: patreon.Data{ID: "300", Type: "address"}}
+	s.AddPledge("10", pledge)
+
+	cassette := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+
+	// Record
+
+	rec, err := NewRecorder(cassette, Record)
+	require.NoError(t, err)
+
+	// Authorization header is added by oauth2 transport before the request reaches the recorder
+	tc := &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"}),
+		Base:   rec,
+	}}
+
+	client := patreon.NewClient(tc, patreon.WithBaseURL(s.URL))
+
+	user, err := client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, "creator@example.com", user.Data.Attributes.Email)
+
+	pledges, err := client.FetchPledges("10", patreon.WithIncludes("address"))
+	require.NoError(t, err)
+	require.Equal(t, "1 Main St", pledges.Included.Address("300").Attributes.Line1)
+
+	require.NoError(t, rec.Save())
+	s.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(data), "secret-token"))
+
+	recorded := Cassette{}
+	require.NoError(t, json.Unmarshal(data, &recorded))
+	require.Len(t, recorded.Interactions, 2)
+	for _, i := range recorded.Interactions {
+		require.Equal(t, Redacted, i.Request.Header.Get("Authorization"))
+		require.Empty(t, i.Response.Header.Get("Content-Length"))
+	}
+	require.False(t, strings.Contains(string(data), "creator@example.com"))
+	require.False(t, strings.Contains(string(data), "1 Main St"))
+
+	// Replay
+
+	rec, err = NewRecorder(cassette, Replay, WithStrictReplay())
+	require.NoError(t, err)
+
+	client = patreon.NewClient(rec.Client(), patreon.WithBaseURL(s.URL))
+
+	user, err = client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, "1", user.Data.ID)
+	require.Equal(t, "redacted@example.com", user.Data.Attributes.Email)
+
+	pledges, err = client.FetchPledges("10", patreon.WithIncludes("address"))
+	require.NoError(t, err)
+	require.Len(t, pledges.Data, 4)
+	require.Equal(t, Redacted, pledges.Included.Address("300").Attributes.Line1)
+
+	// Each interaction is replayed once in strict mode
+	_, err = client.FetchUser()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no recorded interaction for GET "+s.URL+"/oauth2/api/current_user")
+}
+
+func TestReplayNonStrict(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := NewRecorder(cassette, Record)
+	require.NoError(t, err)
+
+	_, err = patreon.NewClient(rec.Client(), patreon.WithBaseURL(s.URL)).FetchUser()
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+
+	rec, err = NewRecorder(cassette, Replay)
+	require.NoError(t, err)
+
+	client := patreon.NewClient(rec.Client(), patreon.WithBaseURL(s.URL))
+
+	for i := 0; i < 2; i++ {
+		_, err = client.FetchUser()
+		require.NoError(t, err)
+	}
+
+	// Unmatched requests hit the network
+	resp, err := client.FetchCampaign()
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+}
+
+func TestRedactBody(t *testing.T) {
+	body := redactBody([]byte(`{"data": {"type": "card", "id": "1", "attributes": {"number": "4242", "payment_token_id": 42, "card_type": "visa"}}}`))
+	require.JSONEq(t, `{"data": {"type": "card", "id": "REDACTED", "attributes": {"number": "REDACTED", "payment_token_id": 0, "card_type": "visa"}}}`, body)
+
+	body = redactBody([]byte(`{"data": {"type": "pledge", "id": "2", "relationships": {"card": {"data": {"type": "card", "id": "bt_12312312"}}}}, "included": [{"type": "card", "id": "bt_12312312", "attributes": {"card_type": "visa"}}]}`))
+	require.JSONEq(t, `{"data": {"type": "pledge", "id": "2", "relationships": {"card": {"data": {"type": "card", "id": "REDACTED"}}}}, "included": [{"type": "card", "id": "REDACTED", "attributes": {"card_type": "visa"}}]}`, body)
+
+	body = redactBody([]byte(`{"data": [{"type": "webhook", "id": "1", "attributes": {"secret": "webhook-secret", "uri": "https://example.com/hook"}}]}`))
+	require.JSONEq(t, `{"data": [{"type": "webhook", "id": "1", "attributes": {"secret": "REDACTED", "uri": "https://example.com/hook"}}]}`, body)
+
+	// Numbers and HTML are kept as is
+	body = redactBody([]byte(`{"data": {"type": "post", "id": "1", "attributes": {"content": "<p>Hi & bye</p>", "app_id": 12345678901234567890}}}`))
+	require.Equal(t, `{"data":{"attributes":{"app_id":12345678901234567890,"content":"<p>Hi & bye</p>"},"id":"1","type":"post"}}`, body)
+
+	require.Equal(t, "contact redacted@example.com", redactBody([]byte("contact max@gmail.com")))
+}
@@ -0,0 +1,504 @@
+// Package patreontest provides a fake Patreon API server for testing code built on top of patreon package.
+//
+// The server keeps seeded users, campaigns, rewards, pledges, tiers and members in memory and serves them
+// via API v1 and v2 endpoints with cursor pagination, include and fields[...] filtering:
+//
+//	srv := patreontest.NewServer()
+//	defer srv.Close()
+//
+//	srv.SetUser(&patreon.User{ID: "1"})
+//	srv.AddCampaign(&patreon.Campaign{ID: "10"})
+//	srv.AddPledge("10", &patreon.Pledge{ID: "100"})
+//
+//	client := patreon.NewClient(nil, patreon.WithBaseURL(srv.URL))
+//
+// WebhookSender simulates signed webhook deliveries to a URL or directly to an http.Handler.
+// Recorder records real API interactions to cassette files and replays them in tests.
+package patreontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mxpv/patreon-go"
+)
+
+// DefaultPageSize is the number of items returned by paginated endpoints when page[count] is not specified.
+const DefaultPageSize = 20
+
+// Server is a stateful fake Patreon API server.
+// Seeding methods may be called at any time, including while requests are being served.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	user      *patreon.User
+	campaigns []*patreon.Campaign
+	pledges   map[string][]*patreon.Pledge
+	members   map[string][]*patreon.Member
+	resources map[patreon.Data]interface{}
+	latency   time.Duration
+	faults    []*fault
+}
+
+type fault struct {
+	path   string
+	status int
+	count  int
+}
+
+// NewServer starts a new fake server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		pledges:   make(map[string][]*patreon.Pledge),
+		members:   make(map[string][]*patreon.Member),
+		resources: make(map[patreon.Data]interface{}),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetUser sets the user who "granted" the access token, served by current_user and identity endpoints.
+func (s *Server) SetUser(user *patreon.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&user.Type, "user")
+	s.user = user
+	s.register(user)
+}
+
+// AddUser adds a user (usually a patron) which may be referenced from pledges and members.
+func (s *Server) AddUser(user *patreon.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&user.Type, "user")
+	s.register(user)
+}
+
+// AddCampaign adds a campaign owned by the current user.
+func (s *Server) AddCampaign(campaign *patreon.Campaign) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&campaign.Type, "campaign")
+	s.campaigns = append(s.campaigns, campaign)
+	s.register(campaign)
+}
+
+// AddReward adds a reward to the campaign and links it via campaign's rewards relationship.
+func (s *Server) AddReward(campaignID string, reward *patreon.Reward) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&reward.Type, "reward")
+	s.register(reward)
+
+	if c := s.campaign(campaignID); c != nil {
+		if c.Relationships.Rewards == nil {
+			c.Relationships.Rewards = &patreon.RewardsRelationship{}
+		}
+
+		c.Relationships.Rewards.Data = append(c.Relationships.Rewards.Data, patreon.Data{ID: reward.ID, Type: reward.Type})
+	}
+}
+
+// AddTier adds a tier to the campaign and links it via campaign's tiers relationship.
+func (s *Server) AddTier(campaignID string, tier *patreon.Tier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&tier.Type, "tier")
+	s.register(tier)
+
+	if c := s.campaign(campaignID); c != nil {
+		if c.Relationships.Tiers == nil {
+			c.Relationships.Tiers = &patreon.TiersRelationship{}
+		}
+
+		c.Relationships.Tiers.Data = append(c.Relationships.Tiers.Data, patreon.Data{ID: tier.ID, Type: tier.Type})
+	}
+}
+
+// AddPledge adds a pledge to the campaign, served by API v1 pledges endpoint.
+// Related patron, reward and address should be seeded separately.
+func (s *Server) AddPledge(campaignID string, pledge *patreon.Pledge) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&pledge.Type, "pledge")
+	s.pledges[campaignID] = append(s.pledges[campaignID], pledge)
+	s.register(pledge)
+}
+
+// AddMember adds a member to the campaign, served by API v2 members endpoints.
+// Related user, tiers and address should be seeded separately.
+func (s *Server) AddMember(campaignID string, member *patreon.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setType(&member.Type, "member")
+	s.members[campaignID] = append(s.members[campaignID], member)
+	s.register(member)
+}
+
+// AddResource adds an arbitrary resource (goal, address, benefit, etc) which may be referenced
+// from relationships of other resources. Resource type and ID must be set.
+func (s *Server) AddResource(resource interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.register(resource)
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// FailNext makes the next count requests to the given path fail with the given HTTP status code.
+// Empty path matches any endpoint. The response body contains JSON:API errors just like Patreon API does.
+func (s *Server) FailNext(path string, status int, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{path: path, status: status, count: count})
+}
+
+func (s *Server) register(resource interface{}) {
+	res, err := newResource(resource)
+	if err != nil {
+		panic(fmt.Sprintf("patreontest: invalid resource: %v", err))
+	}
+
+	if res.Type == "" || res.ID == "" {
+		panic(fmt.Sprintf("patreontest: resource type and ID must be set (got type '%s', id '%s')", res.Type, res.ID))
+	}
+
+	s.resources[patreon.Data{ID: res.ID, Type: res.Type}] = resource
+}
+
+func (s *Server) campaign(id string) *patreon.Campaign {
+	for _, c := range s.campaigns {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) member(id string) *patreon.Member {
+	for _, members := range s.members {
+		for _, m := range members {
+			if m.ID == id {
+				return m
+			}
+		}
+	}
+
+	return nil
+}
+
+// fault returns the status code of the injected error matching the path, if any.
+func (s *Server) fault(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.path != "" && f.path != path {
+			continue
+		}
+
+		f.count--
+		if f.count <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+
+		return f.status
+	}
+
+	return 0
+}
+
+func (s *Server) delay(ctx context.Context) {
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+
+	if latency <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.delay(r.Context())
+
+	if status := s.fault(r.URL.Path); status != 0 {
+		writeError(w, status, "Injected error")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not supported", r.Method))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case path == "/oauth2/api/current_user":
+		s.serveUser(w, r, false)
+	case path == "/api/oauth2/v2/identity":
+		s.serveUser(w, r, true)
+	case path == "/oauth2/api/current_user/campaigns":
+		s.serveCampaigns(w, r, false)
+	case path == "/api/oauth2/v2/campaigns":
+		s.serveCampaigns(w, r, true)
+	default:
+		if id, ok := match(path, "/oauth2/api/campaigns/*/pledges"); ok {
+			s.servePledges(w, r, id)
+		} else if id, ok := match(path, "/api/oauth2/v2/campaigns/*/members"); ok {
+			s.serveMembers(w, r, id)
+		} else if id, ok := match(path, "/api/oauth2/v2/campaigns/*"); ok {
+			s.serveCampaign(w, r, id)
+		} else if id, ok := match(path, "/api/oauth2/v2/members/*"); ok {
+			s.serveMember(w, r, id)
+		} else {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Endpoint %s not found", path))
+		}
+	}
+}
+
+func (s *Server) serveUser(w http.ResponseWriter, r *http.Request, v2 bool) {
+	if s.user == nil {
+		writeError(w, http.StatusUnauthorized, "The server could not verify that you are authorized to access the URL requested")
+		return
+	}
+
+	doc := s.newDocument(r, v2)
+	doc.Data = doc.add(s.user)
+	doc.Links = map[string]string{"self": s.URL + r.URL.Path}
+	doc.write(w)
+}
+
+func (s *Server) serveCampaigns(w http.ResponseWriter, r *http.Request, v2 bool) {
+	doc := s.newDocument(r, v2)
+
+	data := make([]*resource, 0, len(s.campaigns))
+	for _, c := range s.campaigns {
+		data = append(data, doc.add(c))
+	}
+
+	doc.Data = data
+	if v2 {
+		doc.Meta = map[string]interface{}{"pagination": map[string]interface{}{"total": len(data)}}
+	}
+
+	doc.write(w)
+}
+
+func (s *Server) serveCampaign(w http.ResponseWriter, r *http.Request, id string) {
+	c := s.campaign(id)
+	if c == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Campaign %s not found", id))
+		return
+	}
+
+	doc := s.newDocument(r, true)
+	doc.Data = doc.add(c)
+	doc.Links = map[string]string{"self": s.URL + r.URL.Path}
+	doc.write(w)
+}
+
+func (s *Server) servePledges(w http.ResponseWriter, r *http.Request, campaignID string) {
+	if s.campaign(campaignID) == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Campaign %s not found", campaignID))
+		return
+	}
+
+	pledges := s.pledges[campaignID]
+
+	start, end, next, err := paginate(r, len(pledges))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	doc := s.newDocument(r, false)
+
+	data := make([]*resource, 0, end-start)
+	for _, p := range pledges[start:end] {
+		data = append(data, doc.add(p))
+	}
+
+	doc.Data = data
+	doc.Links = map[string]string{"first": s.pageLink(r, "")}
+	if next != "" {
+		doc.Links["next"] = s.pageLink(r, next)
+	}
+
+	doc.Meta = map[string]interface{}{"count": len(pledges)}
+	doc.write(w)
+}
+
+func (s *Server) serveMembers(w http.ResponseWriter, r *http.Request, campaignID string) {
+	if s.campaign(campaignID) == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Campaign %s not found", campaignID))
+		return
+	}
+
+	members := s.members[campaignID]
+
+	start, end, next, err := paginate(r, len(members))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	doc := s.newDocument(r, true)
+
+	data := make([]*resource, 0, end-start)
+	for _, m := range members[start:end] {
+		data = append(data, doc.add(m))
+	}
+
+	pagination := map[string]interface{}{"total": len(members)}
+	if next != "" {
+		pagination["cursors"] = map[string]string{"next": next}
+		doc.Links = map[string]string{"next": s.pageLink(r, next)}
+	}
+
+	doc.Data = data
+	doc.Meta = map[string]interface{}{"pagination": pagination}
+	doc.write(w)
+}
+
+func (s *Server) serveMember(w http.ResponseWriter, r *http.Request, id string) {
+	m := s.member(id)
+	if m == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Member %s not found", id))
+		return
+	}
+
+	doc := s.newDocument(r, true)
+	doc.Data = doc.add(m)
+	doc.Links = map[string]string{"self": s.URL + r.URL.Path}
+	doc.write(w)
+}
+
+// pageLink returns the link to the page starting at cursor, preserving other query parameters.
+func (s *Server) pageLink(r *http.Request, cursor string) string {
+	q := r.URL.Query()
+	if cursor == "" {
+		q.Del("page[cursor]")
+	} else {
+		q.Set("page[cursor]", cursor)
+	}
+
+	return s.URL + r.URL.Path + "?" + q.Encode()
+}
+
+// paginate returns the bounds of the page requested with page[count] and page[cursor] parameters
+// and the cursor of the next page (empty for the last page).
+func paginate(r *http.Request, total int) (int, int, string, error) {
+	q := r.URL.Query()
+
+	size := DefaultPageSize
+	if count := q.Get("page[count]"); count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n <= 0 {
+			return 0, 0, "", fmt.Errorf("invalid page[count] '%s'", count)
+		}
+
+		size = n
+	}
+
+	start := 0
+	if cursor := q.Get("page[cursor]"); cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 || n > total {
+			return 0, 0, "", fmt.Errorf("invalid page[cursor] '%s'", cursor)
+		}
+
+		start = n
+	}
+
+	end := start + size
+	if end >= total {
+		return start, total, "", nil
+	}
+
+	return start, end, strconv.Itoa(end), nil
+}
+
+// match matches the path against the pattern where '*' stands for a single path segment, and returns the segment.
+func match(path, pattern string) (string, bool) {
+	parts := strings.Split(path, "/")
+	expected := strings.Split(pattern, "/")
+	if len(parts) != len(expected) {
+		return "", false
+	}
+
+	id := ""
+	for i := range parts {
+		if expected[i] == "*" {
+			if parts[i] == "" {
+				return "", false
+			}
+
+			id = parts[i]
+		} else if parts[i] != expected[i] {
+			return "", false
+		}
+	}
+
+	return id, true
+}
+
+func setType(typ *string, value string) {
+	if *typ == "" {
+		*typ = value
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	e := patreon.Error{
+		Code:     status,
+		CodeName: strings.ReplaceAll(http.StatusText(status), " ", ""),
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+		Title:    http.StatusText(status),
+	}
+
+	if status == http.StatusTooManyRequests {
+		e.CodeName = "RequestThrottled"
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(patreon.ErrorResponse{Errors: []patreon.Error{e}})
+}
@@ -0,0 +1,243 @@
+package patreontest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mxpv/patreon-go"
+	"github.com/stretchr/testify/require"
+)
+
+func seed(s *Server) {
+	user := &patreon.User{ID: "1"}
+	user.Attributes.FullName = "Creator"
+	user.Attributes.Email = "creator@example.com"
+	s.SetUser(user)
+
+	campaign := &patreon.Campaign{ID: "10"}
+	campaign.Attributes.CreationName = "podcasts"
+	campaign.Attributes.Vanity = "podsync"
+	campaign.Relationships.Creator = &patreon.CreatorRelationship{Data: patreon.Data{ID: "1", Type: "user"}}
+	s.AddCampaign(campaign)
+
+	reward := &patreon.Reward{ID: "100"}
+	reward.Attributes.AmountCents = 500
+	reward.Attributes.Title = "Supporter"
+	s.AddReward("10", reward)
+
+	tier := &patreon.Tier{ID: "200"}
+	tier.Attributes.AmountCents = 500
+	tier.Attributes.Title = "Supporter"
+	s.AddTier("10", tier)
+
+	for _, id := range []string{"2", "3", "4"} {
+		patron := &patreon.User{ID: id}
+		patron.Attributes.FullName = "Patron " + id
+		s.AddUser(patron)
+
+		pledge := &patreon.Pledge{ID: "p" + id}
+		pledge.Attributes.AmountCents = 500
+		pledge.Relationships.Patron = &patreon.PatronRelationship{Data: patreon.Data{ID: id, Type: "user"}}
+		pledge.Relationships.Reward = &patreon.RewardRelationship{Data: patreon.Data{ID: "100", Type: "reward"}}
+		s.AddPledge("10", pledge)
+
+		member := &patreon.Member{ID: "m" + id}
+		member.Attributes.PatronStatus = patreon.PatronStatusActive
+		member.Attributes.FullName = "Patron " + id
+		member.Relationships.User = &patreon.UserRelationship{Data: patreon.Data{ID: id, Type: "user"}}
+		member.Relationships.CurrentlyEntitledTiers = &patreon.TiersRelationship{Data: []patreon.Data{{ID: "200", Type: "tier"}}}
+		s.AddMember("10", member)
+	}
+}
+
+func newClient(s *Server) *patreon.Client {
+	return patreon.NewClient(nil, patreon.WithBaseURL(s.URL))
+}
+
+func TestFetchUser(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	resp, err := newClient(s).FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.Data.ID)
+	require.Equal(t, "user", resp.Data.Type)
+	require.Equal(t, "Creator", resp.Data.Attributes.FullName)
+	require.Equal(t, "creator@example.com", resp.Data.Attributes.Email)
+}
+
+func TestFetchUserUnauthorized(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, err := newClient(s).FetchUser()
+	require.True(t, errors.Is(err, patreon.ErrUnauthorized))
+}
+
+func TestFetchIdentityFields(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	client := newClient(s)
+
+	resp, err := client.FetchIdentity()
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.Data.ID)
+	require.Empty(t, resp.Data.Attributes.FullName)
+
+	resp, err = client.FetchIdentity(patreon.WithFields("user", "full_name"))
+	require.NoError(t, err)
+	require.Equal(t, "Creator", resp.Data.Attributes.FullName)
+	require.Empty(t, resp.Data.Attributes.Email)
+}
+
+func TestFetchCampaignIncludes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	client := newClient(s)
+
+	resp, err := client.FetchCampaign()
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, "podsync", resp.Data[0].Attributes.Vanity)
+	require.Empty(t, resp.Included.Items)
+
+	resp, err = client.FetchCampaign(patreon.WithIncludes(patreon.CampaignDefaultRelations))
+	require.NoError(t, err)
+
+	rewards, err := resp.RewardsOf(&resp.Data[0])
+	require.NoError(t, err)
+	require.Len(t, rewards, 1)
+	require.Equal(t, "Supporter", rewards[0].Attributes.Title)
+
+	creator, err := resp.CreatorOf(&resp.Data[0])
+	require.NoError(t, err)
+	require.Equal(t, "Creator", creator.Attributes.FullName)
+}
+
+func TestFetchCampaignByID(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	client := newClient(s)
+
+	resp, err := client.FetchCampaignByID("10",
+		patreon.WithIncludes("tiers"),
+		patreon.WithFields("campaign", "vanity"),
+		patreon.WithFields("tier", "title"))
+	require.NoError(t, err)
+	require.Equal(t, "podsync", resp.Data.Attributes.Vanity)
+	require.Empty(t, resp.Data.Attributes.CreationName)
+
+	tier := resp.Included.Tier("200")
+	require.NotNil(t, tier)
+	require.Equal(t, "Supporter", tier.Attributes.Title)
+	require.Zero(t, tier.Attributes.AmountCents)
+
+	_, err = client.FetchCampaignByID("404")
+	require.True(t, errors.Is(err, patreon.ErrNotFound))
+}
+
+func TestFetchAllPledges(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	resp, err := newClient(s).FetchAllPledges("10",
+		patreon.WithPageSize(2),
+		patreon.WithIncludes("patron", "reward"))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 3)
+	require.Equal(t, 3, resp.Meta.Count)
+
+	for i, id := range []string{"2", "3", "4"} {
+		require.Equal(t, "p"+id, resp.Data[i].ID)
+
+		patron, err := resp.PatronOf(&resp.Data[i])
+		require.NoError(t, err)
+		require.Equal(t, "Patron "+id, patron.Attributes.FullName)
+
+		reward, err := resp.RewardOf(&resp.Data[i])
+		require.NoError(t, err)
+		require.Equal(t, 500, reward.Attributes.AmountCents)
+	}
+}
+
+func TestFetchMembersPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	client := newClient(s)
+
+	resp, err := client.FetchMembers("10",
+		patreon.WithPageSize(2),
+		patreon.WithIncludes("user", "currently_entitled_tiers"),
+		patreon.WithFields("member", "patron_status"))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, 3, resp.Meta.Pagination.Total)
+	require.Equal(t, "2", resp.Meta.Pagination.Cursors.Next)
+	require.NotEmpty(t, resp.Links.Next)
+	require.Equal(t, patreon.PatronStatusActive, resp.Data[0].Attributes.PatronStatus)
+	require.Empty(t, resp.Data[0].Attributes.FullName)
+	require.Len(t, resp.Included.Users(), 2)
+	require.Len(t, resp.Included.Tiers(), 1)
+
+	resp, err = client.FetchMembers("10", patreon.WithPageSize(2), patreon.WithCursor(resp.Links.Next))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, "m4", resp.Data[0].ID)
+	require.Empty(t, resp.Meta.Pagination.Cursors.Next)
+
+	member, err := client.FetchMember("m3")
+	require.NoError(t, err)
+	require.Equal(t, "m3", member.Data.ID)
+}
+
+func TestFailNext(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	s.FailNext("/oauth2/api/current_user", http.StatusServiceUnavailable, 2)
+
+	client := patreon.NewClient(nil,
+		patreon.WithBaseURL(s.URL),
+		patreon.WithRetryPolicy(patreon.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	// Other endpoints are not affected
+	_, err := client.FetchCampaign()
+	require.NoError(t, err)
+
+	resp, err := client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.Data.ID)
+
+	s.FailNext("", http.StatusTooManyRequests, 1)
+
+	_, err = newClient(s).FetchCampaign()
+	require.True(t, errors.Is(err, patreon.ErrRateLimited))
+}
+
+func TestLatency(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	seed(s)
+
+	s.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := newClient(s).FetchUserContext(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
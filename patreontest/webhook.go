@@ -0,0 +1,172 @@
+package patreontest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/mxpv/patreon-go"
+)
+
+// Event describes a webhook event to deliver.
+type Event struct {
+	// Type is the event type, such as patreon.EventCreatePledge.
+	Type string
+	// Data is the resource the event is about: *patreon.Pledge for pledges:* events and *patreon.Member for members:* events.
+	Data interface{}
+	// Included lists resources referenced by Data relationships (patron, reward, campaign, tiers, etc).
+	Included []interface{}
+}
+
+// PledgeEvent returns a pledges:* event, included resources are usually the patron, reward and campaign.
+func PledgeEvent(event string, pledge *patreon.Pledge, included ...interface{}) Event {
+	return Event{Type: event, Data: pledge, Included: included}
+}
+
+// MemberEvent returns a members:* event, included resources are usually the user, tiers and campaign.
+func MemberEvent(event string, member *patreon.Member, included ...interface{}) Event {
+	return Event{Type: event, Data: member, Included: included}
+}
+
+// MarshalJSON encodes the event payload the way Patreon does: as a JSON:API document with
+// the resource in "data" and related resources in "included". Null relationships are omitted.
+func (e Event) MarshalJSON() ([]byte, error) {
+	if e.Data == nil {
+		return nil, errors.New("patreontest: event data is required")
+	}
+
+	data, err := newResource(e.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	included := make([]*resource, 0, len(e.Included))
+	for _, item := range e.Included {
+		res, err := newResource(item)
+		if err != nil {
+			return nil, err
+		}
+
+		included = append(included, res)
+	}
+
+	return json.Marshal(struct {
+		Data     *resource   `json:"data"`
+		Included []*resource `json:"included"`
+	}{data, included})
+}
+
+// Sign returns the signature of the message, as sent by Patreon in patreon.HeaderSignature header
+// (hex encoded HMAC-MD5 of the message body keyed with the webhook secret).
+func Sign(message []byte, secret string) string {
+	hash := hmac.New(md5.New, []byte(secret))
+	hash.Write(message)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// WebhookSender simulates Patreon webhook deliveries for local development and tests.
+// Events are POSTed to URL, or passed directly to Handler if it's set.
+type WebhookSender struct {
+	// Secret is the webhook secret used to sign messages.
+	Secret string
+	// URL is the address events are delivered to. Ignored when Handler is set.
+	URL string
+	// Handler receives events directly, without network roundtrip.
+	Handler http.Handler
+	// Client is used to deliver events to URL. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+type deliveryOptions struct {
+	invalidSignature bool
+	duplicates       int
+}
+
+type deliveryOption func(*deliveryOptions)
+
+// WithInvalidSignature signs the message with a wrong secret, to test signature verification.
+func WithInvalidSignature() deliveryOption {
+	return func(o *deliveryOptions) {
+		o.invalidSignature = true
+	}
+}
+
+// WithDuplicates delivers the same message n more times, as Patreon may do when retrying failed deliveries.
+func WithDuplicates(n int) deliveryOption {
+	return func(o *deliveryOptions) {
+		o.duplicates = n
+	}
+}
+
+// Send delivers the event and returns HTTP status code of the last delivery.
+func (s *WebhookSender) Send(ctx context.Context, event Event, opts ...deliveryOption) (int, error) {
+	cfg := deliveryOptions{}
+	for _, fn := range opts {
+		fn(&cfg)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	signature := Sign(body, s.Secret)
+	if cfg.invalidSignature {
+		signature = Sign(body, "invalid"+s.Secret)
+	}
+
+	status := 0
+	for i := 0; i <= cfg.duplicates; i++ {
+		status, err = s.deliver(ctx, event.Type, body, signature)
+		if err != nil {
+			return status, err
+		}
+	}
+
+	return status, nil
+}
+
+func (s *WebhookSender) deliver(ctx context.Context, event string, body []byte, signature string) (int, error) {
+	addr := s.URL
+	if s.Handler != nil && addr == "" {
+		addr = "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Patreon HTTP Robot")
+	req.Header.Set(patreon.HeaderEventType, event)
+	req.Header.Set(patreon.HeaderSignature, signature)
+
+	if s.Handler != nil {
+		rec := httptest.NewRecorder()
+		s.Handler.ServeHTTP(rec, req)
+		return rec.Code, nil
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,114 @@
+package patreontest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mxpv/patreon-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPledgeEvent(t *testing.T) {
+	var received []*patreon.WebhookPledge
+	handler := &patreon.WebhookHandler{
+		Secret: "secret",
+		OnPledgeCreate: func(ctx context.Context, pledge *patreon.WebhookPledge) error {
+			received = append(received, pledge)
+			return nil
+		},
+	}
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	patron := &patreon.User{Type: "user", ID: "2"}
+	patron.Attributes.FullName = "Patron"
+
+	pledge := &patreon.Pledge{Type: "pledge", ID: "100"}
+	pledge.Attributes.AmountCents = 500
+	pledge.Relationships.Patron = &patreon.PatronRelationship{Data: patreon.Data{ID: "2", Type: "user"}}
+
+	sender := &WebhookSender{Secret: "secret", URL: server.URL}
+
+	status, err := sender.Send(context.Background(), PledgeEvent(patreon.EventCreatePledge, pledge, patron))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+
+	require.Len(t, received, 1)
+	require.Equal(t, "100", received[0].Data.ID)
+	require.Equal(t, 500, received[0].Data.Attributes.AmountCents)
+	require.Nil(t, received[0].Data.Relationships.Reward)
+
+	user := received[0].Included.User("2")
+	require.NotNil(t, user)
+	require.Equal(t, "Patron", user.Attributes.FullName)
+}
+
+func TestSendMemberEvent(t *testing.T) {
+	var received []*patreon.WebhookMember
+	handler := &patreon.WebhookHandler{
+		Secret: "secret",
+		OnMemberUpdate: func(ctx context.Context, member *patreon.WebhookMember) error {
+			received = append(received, member)
+			return nil
+		},
+	}
+
+	user := &patreon.User{Type: "user", ID: "2"}
+	tier := &patreon.Tier{Type: "tier", ID: "200"}
+	tier.Attributes.Title = "Supporter"
+
+	member := &patreon.Member{Type: "member", ID: "m2"}
+	member.Attributes.PatronStatus = patreon.PatronStatusActive
+	member.Relationships.User = &patreon.UserRelationship{Data: patreon.Data{ID: "2", Type: "user"}}
+	member.Relationships.CurrentlyEntitledTiers = &patreon.TiersRelationship{Data: []patreon.Data{{ID: "200", Type: "tier"}}}
+
+	sender := &WebhookSender{Secret: "secret", Handler: handler}
+
+	status, err := sender.Send(context.Background(),
+		MemberEvent(patreon.EventUpdateMember, member, user, tier),
+		WithDuplicates(2))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+
+	require.Len(t, received, 3)
+	for _, m := range received {
+		require.Equal(t, "m2", m.Data.ID)
+		require.Equal(t, patreon.PatronStatusActive, m.Data.Attributes.PatronStatus)
+		require.Equal(t, "2", m.User.ID)
+		require.Len(t, m.Tiers, 1)
+		require.Equal(t, "Supporter", m.Tiers[0].Attributes.Title)
+	}
+}
+
+func TestSendInvalidSignature(t *testing.T) {
+	called := false
+	handler := &patreon.WebhookHandler{
+		Secret: "secret",
+		OnPledgeDelete: func(ctx context.Context, pledge *patreon.WebhookPledge) error {
+			called = true
+			return nil
+		},
+	}
+
+	sender := &WebhookSender{Secret: "secret", Handler: handler}
+
+	status, err := sender.Send(context.Background(),
+		PledgeEvent(patreon.EventDeletePledge, &patreon.Pledge{Type: "pledge", ID: "100"}),
+		WithInvalidSignature())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, status)
+	require.False(t, called)
+}
+
+func TestSign(t *testing.T) {
+	body, err := json.Marshal(PledgeEvent(patreon.EventCreatePledge, &patreon.Pledge{Type: "pledge", ID: "100"}))
+	require.NoError(t, err)
+
+	ok, err := patreon.VerifySignature(body, "secret", Sign(body, "secret"))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -39,4 +39,69 @@ type PledgeResponse struct {
 	Meta struct {
 		Count int `json:"count"`
 	} `json:"meta"`
+
+	documentSource
+}
+
+// PatronOf returns the included user who made the pledge.
+// It returns nil if the pledge has no patron relationship and ErrNotIncluded if the patron is missing from includes.
+func (r *PledgeResponse) PatronOf(p *Pledge) (*User, error) {
+	if p.Relationships.Patron == nil || p.Relationships.Patron.Data.ID == "" {
+		return nil, nil
+	}
+
+	data := p.Relationships.Patron.Data
+	item, err := r.Included.resolve(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := item.(*User)
+	if !ok {
+		return nil, unexpectedType(data, item)
+	}
+
+	return user, nil
+}
+
+// RewardOf returns the included reward of the pledge.
+// It returns nil if the pledge has no reward and ErrNotIncluded if the reward is missing from includes.
+func (r *PledgeResponse) RewardOf(p *Pledge) (*Reward, error) {
+	if p.Relationships.Reward == nil || p.Relationships.Reward.Data.ID == "" {
+		return nil, nil
+	}
+
+	data := p.Relationships.Reward.Data
+	item, err := r.Included.resolve(data)
+	if err != nil {
+		return nil, err
+	}
+
+	reward, ok := item.(*Reward)
+	if !ok {
+		return nil, unexpectedType(data, item)
+	}
+
+	return reward, nil
+}
+
+// AddressOf returns the included shipping address of the pledge.
+// It returns nil if the pledge has no address and ErrNotIncluded if the address is missing from includes.
+func (r *PledgeResponse) AddressOf(p *Pledge) (*Address, error) {
+	if p.Relationships.Address == nil || p.Relationships.Address.Data.ID == "" {
+		return nil, nil
+	}
+
+	data := p.Relationships.Address.Data
+	item, err := r.Included.resolve(data)
+	if err != nil {
+		return nil, err
+	}
+
+	address, ok := item.(*Address)
+	if !ok {
+		return nil, unexpectedType(data, item)
+	}
+
+	return address, nil
 }
@@ -0,0 +1,23 @@
+package patreon
+
+// PledgeEvent represents a change of member's pledge or a payment (API v2).
+// Valid relationships: campaign, patron, tier.
+type PledgeEvent struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		AmountCents         int      `json:"amount_cents"`
+		CurrencyCode        string   `json:"currency_code"`
+		Date                NullTime `json:"date"`
+		PaymentStatus       string   `json:"payment_status"`
+		PledgePaymentStatus string   `json:"pledge_payment_status"`
+		TierID              string   `json:"tier_id"`
+		TierTitle           string   `json:"tier_title"`
+		Type                string   `json:"type"`
+	} `json:"attributes"`
+	Relationships struct {
+		Campaign *CampaignRelationship `json:"campaign,omitempty"`
+		Patron   *PatronRelationship   `json:"patron,omitempty"`
+		Tier     *TierRelationship     `json:"tier,omitempty"`
+	} `json:"relationships"`
+}
@@ -0,0 +1,146 @@
+package patreon
+
+import (
+	"context"
+)
+
+// PledgeIterator walks through all the pledges of a campaign, fetching pages from Patreon on demand.
+// Only one page is kept in memory at a time, so it's suitable for campaigns with a large number of patrons.
+//
+//	it := client.IteratePledges(campaignID, WithPageSize(100))
+//	for it.Next() {
+//		pledge := it.Pledge()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type PledgeIterator struct {
+	ctx        context.Context
+	client     *Client
+	campaignID string
+	opts       []requestOption
+
+	page   *PledgeResponse
+	index  int
+	cursor string
+	done   bool
+	err    error
+}
+
+// IteratePledges returns an iterator over all the pledges to the provided campaignId.
+// Request options (such as WithPageSize, WithIncludes and WithFields) are applied to every page request.
+func (c *Client) IteratePledges(campaignId string, opts ...requestOption) *PledgeIterator {
+	return c.IteratePledgesContext(context.Background(), campaignId, opts...)
+}
+
+// IteratePledgesContext is like IteratePledges, but uses ctx to control cancellation and deadline of page requests.
+func (c *Client) IteratePledgesContext(ctx context.Context, campaignId string, opts ...requestOption) *PledgeIterator {
+	return &PledgeIterator{
+		ctx:        ctx,
+		client:     c,
+		campaignID: campaignId,
+		opts:       opts,
+	}
+}
+
+// FetchAllPledges fetches all pages of pledges to the provided campaignId and merges them into a single response.
+// Included items are deduplicated across pages.
+func (c *Client) FetchAllPledges(campaignId string, opts ...requestOption) (*PledgeResponse, error) {
+	return c.FetchAllPledgesContext(context.Background(), campaignId, opts...)
+}
+
+// FetchAllPledgesContext is like FetchAllPledges, but uses ctx to control cancellation and deadline of page requests.
+func (c *Client) FetchAllPledgesContext(ctx context.Context, campaignId string, opts ...requestOption) (*PledgeResponse, error) {
+	it := c.IteratePledgesContext(ctx, campaignId, opts...)
+
+	resp := &PledgeResponse{}
+
+	for first := true; it.nextPage(); first = false {
+		page := it.page
+		if first {
+			resp.Links.First = page.Links.First
+			resp.Meta = page.Meta
+		}
+
+		resp.Data = append(resp.Data, page.Data...)
+
+		for _, item := range page.Included.Items {
+			resp.Included.add(item)
+		}
+	}
+
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	return resp, nil
+}
+
+// Next advances the iterator to the next pledge, fetching the next page if needed.
+// It returns false when there are no more pledges or an error occurred.
+func (it *PledgeIterator) Next() bool {
+	for {
+		if it.page != nil && it.index+1 < len(it.page.Data) {
+			it.index++
+			return true
+		}
+
+		if !it.nextPage() {
+			return false
+		}
+	}
+}
+
+// Pledge returns the current pledge. It must only be called after Next returned true.
+func (it *PledgeIterator) Pledge() *Pledge {
+	if it.page == nil || it.index < 0 || it.index >= len(it.page.Data) {
+		return nil
+	}
+
+	return &it.page.Data[it.index]
+}
+
+// Included returns the included items of the page the current pledge belongs to.
+func (it *PledgeIterator) Included() *Includes {
+	if it.page == nil {
+		return nil
+	}
+
+	return &it.page.Included
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (it *PledgeIterator) Err() error {
+	return it.err
+}
+
+// nextPage fetches the next page of pledges. It returns false when the last page has been reached or on error.
+func (it *PledgeIterator) nextPage() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	opts := it.opts
+	if it.cursor != "" {
+		opts = append(opts[:len(opts):len(opts)], WithCursor(it.cursor))
+	}
+
+	resp, err := it.client.FetchPledgesContext(it.ctx, it.campaignID, opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = resp
+	it.index = -1
+
+	// Stop when there is no next page (or server keeps returning the same one)
+	next := resp.Links.Next
+	if next == "" || next == it.cursor {
+		it.done = true
+	}
+
+	it.cursor = next
+	return true
+}
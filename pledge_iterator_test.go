@@ -0,0 +1,110 @@
+package patreon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupPledgePages(t *testing.T) {
+	mux.HandleFunc("/oauth2/api/campaigns/123/pledges", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "2", request.URL.Query().Get("page[count]"))
+
+		switch request.URL.Query().Get("page[cursor]") {
+		case "":
+			fmt.Fprintf(writer, pledgePageResp, `{"id":"1","type":"pledge"},{"id":"2","type":"pledge"}`, "1", server.URL+"/oauth2/api/campaigns/123/pledges?page%5Bcount%5D=2&page%5Bcursor%5D=next")
+		case "next":
+			fmt.Fprintf(writer, pledgePageResp, `{"id":"3","type":"pledge"}`, "2", "")
+		default:
+			t.Fatalf("unexpected cursor %q", request.URL.Query().Get("page[cursor]"))
+		}
+	})
+}
+
+func TestPledgeIterator(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPledgePages(t)
+
+	var ids []string
+
+	it := client.IteratePledges("123", WithPageSize(2))
+	for it.Next() {
+		ids = append(ids, it.Pledge().ID)
+		require.Len(t, it.Included().Items, 2)
+	}
+
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"1", "2", "3"}, ids)
+	require.False(t, it.Next())
+}
+
+func TestPledgeIteratorError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/api/campaigns/123/pledges", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(writer, errorResp)
+	})
+
+	it := client.IteratePledges("123")
+	require.False(t, it.Next())
+	require.Nil(t, it.Pledge())
+	require.Error(t, it.Err())
+}
+
+func TestPledgeIteratorCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPledgePages(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := client.IteratePledgesContext(ctx, "123", WithPageSize(2))
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+
+	cancel()
+
+	require.False(t, it.Next())
+	require.Equal(t, context.Canceled, it.Err())
+}
+
+func TestFetchAllPledges(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPledgePages(t)
+
+	resp, err := client.FetchAllPledges("123", WithPageSize(2))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 3)
+	require.Equal(t, 3, resp.Meta.Count)
+	require.Empty(t, resp.Links.Next)
+
+	// Reward is included in both pages and must be deduplicated
+	require.Len(t, resp.Included.Items, 3)
+
+	reward, ok := resp.Included.Items[1].(*Reward)
+	require.True(t, ok)
+	require.Equal(t, "10", reward.ID)
+
+	user, ok := resp.Included.Items[2].(*User)
+	require.True(t, ok)
+	require.Equal(t, "2", user.ID)
+}
+
+const pledgePageResp = `
+{
+    "data": [%s],
+    "included": [
+        {"id": "%[2]s", "type": "user", "attributes": {}},
+        {"id": "10", "type": "reward", "attributes": {}}
+    ],
+    "links": {"next": "%[3]s"},
+    "meta": {"count": 3}
+}
+`
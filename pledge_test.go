@@ -1,6 +1,8 @@
 package patreon
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -54,6 +56,73 @@ func TestFetchPledges(t *testing.T) {
 	require.Equal(t, "https://www.patreon.com/api/rewards/21321321321", reward.Links.Related)
 }
 
+func TestPledgeResponseResolvers(t *testing.T) {
+	resp := &PledgeResponse{}
+	require.NoError(t, json.Unmarshal([]byte(resolvePledgesResp), resp))
+
+	first := &resp.Data[0]
+
+	patron, err := resp.PatronOf(first)
+	require.NoError(t, err)
+	require.Equal(t, "Max", patron.Attributes.FullName)
+
+	reward, err := resp.RewardOf(first)
+	require.NoError(t, err)
+	require.Equal(t, 100, reward.Attributes.AmountCents)
+
+	address, err := resp.AddressOf(first)
+	require.NoError(t, err)
+	require.Equal(t, "Kyiv", address.Attributes.City)
+
+	// Second pledge has no reward and address, and its patron is not included
+
+	second := &resp.Data[1]
+
+	_, err = resp.PatronOf(second)
+	require.True(t, errors.Is(err, ErrNotIncluded))
+	require.Equal(t, "user '2': resource is not included", err.Error())
+
+	reward, err = resp.RewardOf(second)
+	require.NoError(t, err)
+	require.Nil(t, reward)
+
+	address, err = resp.AddressOf(second)
+	require.NoError(t, err)
+	require.Nil(t, address)
+}
+
+const resolvePledgesResp = `
+{
+    "data": [
+        {
+            "attributes": {"amount_cents": 100},
+            "id": "10",
+            "relationships": {
+                "address": {"data": {"id": "30", "type": "address"}},
+                "patron": {"data": {"id": "1", "type": "user"}},
+                "reward": {"data": {"id": "20", "type": "reward"}}
+            },
+            "type": "pledge"
+        },
+        {
+            "attributes": {"amount_cents": 100},
+            "id": "11",
+            "relationships": {
+                "address": {"data": null},
+                "patron": {"data": {"id": "2", "type": "user"}},
+                "reward": {"data": null}
+            },
+            "type": "pledge"
+        }
+    ],
+    "included": [
+        {"attributes": {"full_name": "Max"}, "id": "1", "type": "user"},
+        {"attributes": {"amount_cents": 100}, "id": "20", "type": "reward"},
+        {"attributes": {"city": "Kyiv"}, "id": "30", "type": "address"}
+    ]
+}
+`
+
 const fetchPledgesResp = `
 {
     "data": [
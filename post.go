@@ -0,0 +1,59 @@
+package patreon
+
+// Post represents content published by a campaign (API v2).
+// Valid relationships: user, campaign.
+type Post struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		AppID       int        `json:"app_id"`
+		AppStatus   string     `json:"app_status"`
+		Content     string     `json:"content"`
+		EmbedData   *PostEmbed `json:"embed_data"`
+		EmbedURL    string     `json:"embed_url"`
+		IsPaid      bool       `json:"is_paid"`
+		IsPublic    bool       `json:"is_public"`
+		PublishedAt NullTime   `json:"published_at"`
+		Title       string     `json:"title"`
+		URL         string     `json:"url"`
+	} `json:"attributes"`
+	Relationships struct {
+		User     *UserRelationship     `json:"user,omitempty"`
+		Campaign *CampaignRelationship `json:"campaign,omitempty"`
+	} `json:"relationships"`
+}
+
+// PostEmbed represents media embedded into a post.
+type PostEmbed struct {
+	Description string `json:"description"`
+	HTML        string `json:"html"`
+	Provider    string `json:"provider"`
+	ProviderURL string `json:"provider_url"`
+	Subject     string `json:"subject"`
+	URL         string `json:"url"`
+}
+
+// PostResponse wraps Patreon's fetch post API response
+type PostResponse struct {
+	Data     Post     `json:"data"`
+	Included Includes `json:"included"`
+	Links    struct {
+		Self string `json:"self"`
+	} `json:"links"`
+
+	documentSource
+}
+
+// PostsResponse wraps Patreon's campaign posts API response
+type PostsResponse struct {
+	Data     []Post   `json:"data"`
+	Included Includes `json:"included"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Meta struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"meta"`
+
+	documentSource
+}
@@ -0,0 +1,151 @@
+package patreon
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPosts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/campaigns/278915/posts", func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		require.Equal(t, "title,url,is_paid,published_at", query.Get("fields[post]"))
+		require.Equal(t, "20", query.Get("page[count]"))
+		fmt.Fprint(writer, fetchPostsResp)
+	})
+
+	resp, err := client.FetchPosts("278915",
+		WithFields("post", "title", "url", "is_paid", "published_at"),
+		WithPageSize(20))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, "post", resp.Data[0].Type)
+	require.Equal(t, "1001", resp.Data[0].ID)
+	require.Equal(t, "Episode 1", resp.Data[0].Attributes.Title)
+	require.True(t, resp.Data[0].Attributes.IsPaid)
+	require.True(t, resp.Data[0].Attributes.PublishedAt.Valid)
+	require.False(t, resp.Data[1].Attributes.PublishedAt.Valid)
+
+	require.Equal(t, 40, resp.Meta.Pagination.Total)
+	require.Equal(t, "Njk0", resp.Meta.Pagination.Cursors.Next)
+	require.NotEmpty(t, resp.Links.Next)
+}
+
+func TestFetchPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/posts/1001", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, fetchPostResp)
+	})
+
+	resp, err := client.FetchPost("1001")
+	require.NoError(t, err)
+
+	attrs := resp.Data.Attributes
+	require.Equal(t, "Episode 1", attrs.Title)
+	require.Equal(t, "<p>Hello patrons</p>", attrs.Content)
+	require.Equal(t, "/posts/episode-1-1001", attrs.URL)
+	require.True(t, attrs.IsPaid)
+	require.False(t, attrs.IsPublic)
+	require.Equal(t, 42, attrs.AppID)
+	require.Equal(t, "approved", attrs.AppStatus)
+	require.Equal(t, "https://www.youtube.com/watch?v=123", attrs.EmbedURL)
+	require.NotNil(t, attrs.EmbedData)
+	require.Equal(t, "YouTube", attrs.EmbedData.Provider)
+	require.Equal(t, "Episode 1 video", attrs.EmbedData.Subject)
+
+	require.NotNil(t, resp.Data.Relationships.Campaign)
+	require.Equal(t, "278915", resp.Data.Relationships.Campaign.Data.ID)
+
+	require.Len(t, resp.Included.Items, 1)
+	_, ok := resp.Included.Items[0].(*Campaign)
+	require.True(t, ok)
+}
+
+const fetchPostsResp = `
+{
+    "data": [
+        {
+            "attributes": {
+                "is_paid": true,
+                "published_at": "2018-05-01T10:00:00+00:00",
+                "title": "Episode 1",
+                "url": "/posts/episode-1-1001"
+            },
+            "id": "1001",
+            "type": "post"
+        },
+        {
+            "attributes": {
+                "is_paid": false,
+                "published_at": null,
+                "title": "Draft",
+                "url": "/posts/draft-1002"
+            },
+            "id": "1002",
+            "type": "post"
+        }
+    ],
+    "links": {
+        "next": "https://www.patreon.com/api/oauth2/v2/campaigns/278915/posts?page%5Bcount%5D=20&page%5Bcursor%5D=Njk0"
+    },
+    "meta": {
+        "pagination": {
+            "cursors": {
+                "next": "Njk0"
+            },
+            "total": 40
+        }
+    }
+}
+`
+
+const fetchPostResp = `
+{
+    "data": {
+        "attributes": {
+            "app_id": 42,
+            "app_status": "approved",
+            "content": "<p>Hello patrons</p>",
+            "embed_data": {
+                "description": "First episode",
+                "html": null,
+                "provider": "YouTube",
+                "provider_url": "https://www.youtube.com/",
+                "subject": "Episode 1 video",
+                "url": "https://www.youtube.com/watch?v=123"
+            },
+            "embed_url": "https://www.youtube.com/watch?v=123",
+            "is_paid": true,
+            "is_public": false,
+            "published_at": "2018-05-01T10:00:00+00:00",
+            "title": "Episode 1",
+            "url": "/posts/episode-1-1001"
+        },
+        "id": "1001",
+        "relationships": {
+            "campaign": {
+                "data": {"id": "278915", "type": "campaign"}
+            }
+        },
+        "type": "post"
+    },
+    "included": [
+        {
+            "attributes": {},
+            "id": "278915",
+            "type": "campaign"
+        }
+    ],
+    "links": {
+        "self": "https://www.patreon.com/api/oauth2/v2/posts/1001"
+    }
+}
+`
@@ -0,0 +1,143 @@
+package patreon
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucketRecovery is the time it takes for a throttled TokenBucket to restore its rate from zero to the configured limit.
+const tokenBucketRecovery = time.Minute
+
+// RateLimiter limits the rate of requests sent by Client.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type RateLimiter interface {
+	// Wait blocks until a request is allowed to proceed or ctx is done.
+	Wait(ctx context.Context) error
+
+	// Throttled is called when Patreon rejects a request with HTTP 429.
+	// retryAfter is the delay suggested by the server, or zero if unknown.
+	Throttled(retryAfter time.Duration)
+}
+
+// WithRateLimiter makes every request made by the client wait on the given limiter.
+// Share the same client (or limiter) between goroutines to enforce a common limit.
+func WithRateLimiter(limiter RateLimiter) clientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// TokenBucket is a RateLimiter which allows up to burst requests at once and refills at the given rate.
+// When the server reports throttling, the rate is halved and all requests are paused for the suggested duration,
+// then the rate gradually recovers to the configured limit.
+type TokenBucket struct {
+	mu     sync.Mutex
+	limit  float64
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a new limiter allowing rate requests per second with bursts of up to burst requests.
+// The rate must be positive, otherwise NewTokenBucket panics.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) {
+		panic("patreon: non-positive rate for NewTokenBucket")
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		limit:  rate,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Rate returns the current number of requests allowed per second.
+func (b *TokenBucket) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(time.Now())
+	return b.rate
+}
+
+// Wait implements RateLimiter.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.advance(time.Now())
+
+	// Reserve a token, negative balance means the request has to wait for the bucket to refill
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	b.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		// Return the reservation, so other requests don't have to wait for it
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Throttled implements RateLimiter.
+func (b *TokenBucket) Throttled(retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(time.Now())
+
+	b.rate /= 2
+	if floor := b.limit / 16; b.rate < floor {
+		b.rate = floor
+	}
+
+	// Pause subsequent requests by putting the bucket into debt
+	debt := -retryAfter.Seconds() * b.rate
+	if b.tokens > debt {
+		b.tokens = debt
+	}
+}
+
+// advance refills the bucket and recovers the rate according to the time passed since the last call.
+func (b *TokenBucket) advance(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.last = now
+
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.rate += b.limit * elapsed / tokenBucketRecovery.Seconds()
+	if b.rate > b.limit {
+		b.rate = b.limit
+	}
+}
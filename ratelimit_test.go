@@ -0,0 +1,118 @@
+package patreon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testLimiter struct {
+	waits     int
+	throttled []time.Duration
+}
+
+func (l *testLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return nil
+}
+
+func (l *testLimiter) Throttled(retryAfter time.Duration) {
+	l.throttled = append(l.throttled, retryAfter)
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket := NewTokenBucket(10, 2)
+
+	start := time.Now()
+	require.NoError(t, bucket.Wait(context.Background()))
+	require.NoError(t, bucket.Wait(context.Background()))
+	require.True(t, time.Since(start) < 50*time.Millisecond)
+
+	require.NoError(t, bucket.Wait(context.Background()))
+	require.True(t, time.Since(start) >= 80*time.Millisecond)
+}
+
+func TestTokenBucketInvalidRate(t *testing.T) {
+	require.Panics(t, func() { NewTokenBucket(0, 1) })
+	require.Panics(t, func() { NewTokenBucket(-1, 1) })
+}
+
+func TestTokenBucketCanceled(t *testing.T) {
+	bucket := NewTokenBucket(1, 1)
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.Equal(t, context.DeadlineExceeded, bucket.Wait(ctx))
+
+	// Canceled request must return its reservation
+	bucket.mu.Lock()
+	require.True(t, bucket.tokens > -0.5)
+	bucket.mu.Unlock()
+}
+
+func TestTokenBucketThrottled(t *testing.T) {
+	bucket := NewTokenBucket(100, 1)
+
+	bucket.Throttled(50 * time.Millisecond)
+	require.InDelta(t, 50, bucket.Rate(), 1)
+
+	start := time.Now()
+	require.NoError(t, bucket.Wait(context.Background()))
+	require.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		bucket.Throttled(0)
+	}
+
+	require.InDelta(t, 100.0/16, bucket.Rate(), 0.1)
+}
+
+func TestTokenBucketConcurrent(t *testing.T) {
+	bucket := NewTokenBucket(1000, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				require.NoError(t, bucket.Wait(context.Background()))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := &testLimiter{}
+	client = NewClient(nil, WithBaseURL(server.URL), WithRateLimiter(limiter), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		if attempts == 1 {
+			writer.Header().Set("Retry-After", "0")
+			writer.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(writer, throttledResp)
+			return
+		}
+
+		fmt.Fprint(writer, currentUserResp)
+	})
+
+	_, err := client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, 2, limiter.waits)
+	require.Equal(t, []time.Duration{0}, limiter.throttled)
+}
@@ -0,0 +1,56 @@
+package patreon
+
+import (
+	"sync"
+)
+
+var (
+	resourceTypesMu sync.RWMutex
+	resourceTypes   = map[string]func() interface{}{
+		"user":         func() interface{} { return &User{} },
+		"reward":       func() interface{} { return &Reward{} },
+		"goal":         func() interface{} { return &Goal{} },
+		"campaign":     func() interface{} { return &Campaign{} },
+		"pledge":       func() interface{} { return &Pledge{} },
+		"card":         func() interface{} { return &Card{} },
+		"address":      func() interface{} { return &Address{} },
+		"member":       func() interface{} { return &Member{} },
+		"tier":         func() interface{} { return &Tier{} },
+		"pledge-event": func() interface{} { return &PledgeEvent{} },
+		"benefit":      func() interface{} { return &Benefit{} },
+		"post":         func() interface{} { return &Post{} },
+		"webhook":      func() interface{} { return &Webhook{} },
+	}
+)
+
+// RegisterResourceType registers a factory used by Includes to decode included resources of the given type.
+// The factory must return a pointer to a new struct, which will be passed to json.Unmarshal.
+// Registering a built-in type (such as "user") replaces its default struct, note that typed helpers
+// (such as Includes.User) only return the built-in structs.
+// It's safe to call RegisterResourceType concurrently, though it's typically called from init.
+func RegisterResourceType(name string, factory func() interface{}) {
+	resourceTypesMu.Lock()
+	defer resourceTypesMu.Unlock()
+
+	resourceTypes[name] = factory
+}
+
+// WithResourceType registers a factory for included resources of the given type for this client only,
+// overriding types registered with RegisterResourceType.
+func WithResourceType(name string, factory func() interface{}) clientOption {
+	return func(c *Client) {
+		if c.types == nil {
+			c.types = make(map[string]func() interface{})
+		}
+
+		c.types[name] = factory
+	}
+}
+
+// lookupResourceType returns the globally registered factory for the given type, or nil if there is none.
+func lookupResourceType(name string) func() interface{} {
+	resourceTypesMu.RLock()
+	defer resourceTypesMu.RUnlock()
+
+	return resourceTypes[name]
+}
@@ -0,0 +1,96 @@
+package patreon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCategory struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+type testUser struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		Vanity string `json:"vanity"`
+	} `json:"attributes"`
+}
+
+func TestRegisterResourceType(t *testing.T) {
+	RegisterResourceType("category", func() interface{} { return &testCategory{} })
+	defer func() {
+		resourceTypesMu.Lock()
+		delete(resourceTypes, "category")
+		resourceTypesMu.Unlock()
+	}()
+
+	includes := Includes{Strict: true}
+	err := json.Unmarshal([]byte(customIncludeJson), &includes)
+	require.NoError(t, err)
+	require.Len(t, includes.Items, 2)
+
+	_, ok := includes.Items[0].(*User)
+	require.True(t, ok)
+
+	category, ok := includes.Find(Data{ID: "7", Type: "category"}).(*testCategory)
+	require.True(t, ok)
+	require.Equal(t, "Podcasts", category.Attributes.Name)
+}
+
+func TestWithResourceType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL),
+		WithResourceType("category", func() interface{} { return &testCategory{} }),
+		WithResourceType("user", func() interface{} { return &testUser{} }))
+
+	mux.HandleFunc("/oauth2/api/current_user/campaigns", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"data": [], "included": %s}`, customIncludeJson)
+	})
+
+	resp, err := client.FetchCampaign()
+	require.NoError(t, err)
+	require.Len(t, resp.Included.Items, 2)
+
+	user, ok := resp.Included.Items[0].(*testUser)
+	require.True(t, ok)
+	require.Equal(t, "podsync", user.Attributes.Vanity)
+
+	_, ok = resp.Included.Items[1].(*testCategory)
+	require.True(t, ok)
+
+	// Global registry must not be affected by client overrides
+	includes := Includes{}
+	require.NoError(t, json.Unmarshal([]byte(customIncludeJson), &includes))
+
+	_, ok = includes.Items[0].(*User)
+	require.True(t, ok)
+
+	_, ok = includes.Items[1].(*RawResource)
+	require.True(t, ok)
+}
+
+const customIncludeJson = `
+[
+	{
+		"attributes": {"vanity": "podsync"},
+		"id": "2822191",
+		"type": "user"
+	},
+	{
+		"attributes": {"name": "Podcasts"},
+		"id": "7",
+		"type": "category"
+	}
+]
+`
@@ -11,6 +11,14 @@ type Meta struct {
 	Count int `json:"count"`
 }
 
+// Pagination represents API v2 pagination details.
+type Pagination struct {
+	Total   int `json:"total"`
+	Cursors struct {
+		Next string `json:"next"`
+	} `json:"cursors"`
+}
+
 // CategoriesRelationship represents 'categories' include.
 type CategoriesRelationship struct {
 	Data []Data `json:"data"`
@@ -91,3 +99,31 @@ type AddressRelationship struct {
 		Related string `json:"related"`
 	} `json:"links"`
 }
+
+// MembershipsRelationship represents 'memberships' include.
+type MembershipsRelationship struct {
+	Data []Data `json:"data"`
+}
+
+// TiersRelationship represents 'tiers' and 'currently_entitled_tiers' includes.
+type TiersRelationship struct {
+	Data []Data `json:"data"`
+}
+
+// PledgeHistoryRelationship represents 'pledge_history' include.
+type PledgeHistoryRelationship struct {
+	Data []Data `json:"data"`
+}
+
+// TierRelationship represents 'tier' include.
+type TierRelationship struct {
+	Data  Data `json:"data"`
+	Links struct {
+		Related string `json:"related"`
+	} `json:"links"`
+}
+
+// BenefitsRelationship represents 'benefits' include.
+type BenefitsRelationship struct {
+	Data []Data `json:"data"`
+}
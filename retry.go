@@ -0,0 +1,172 @@
+package patreon
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultRetryPolicy is a reasonable retry policy for background jobs talking to Patreon API.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryPolicy controls how Client retries requests failed with transient errors
+// (network errors, HTTP 429, 500, 502, 503 and 504).
+// Only idempotent GET requests are retried. Zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Values less than 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. The delay doubles with every subsequent attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. Zero means no limit.
+	MaxDelay time.Duration
+	// Jitter randomizes delays by the given fraction (0.2 means +/- 20%) to avoid retrying in lockstep.
+	Jitter float64
+	// IgnoreRetryAfter disables waiting for the duration suggested by the server via Retry-After header.
+	IgnoreRetryAfter bool
+}
+
+// WithRetryPolicy enables retries of failed requests according to the given policy.
+func WithRetryPolicy(policy RetryPolicy) clientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// delay returns the time to wait before the next attempt.
+// wait is the delay suggested by the server, if any.
+func (p RetryPolicy) delay(attempt int, wait time.Duration) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+
+	if p.Jitter > 0 {
+		d += time.Duration(float64(d) * p.Jitter * (2*rand.Float64() - 1))
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if !p.IgnoreRetryAfter && wait > d {
+		d = wait
+	}
+
+	return d
+}
+
+// shouldRetry reports whether a request failed with the given HTTP status code (zero if no response was received)
+// and error may succeed if retried.
+func shouldRetry(ctx context.Context, status int, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	// Network errors are safe to retry as GET requests are idempotent.
+	// Other errors without response (e.g. returned by middleware) are final.
+	if isNetworkError(err) || isRetryableStatus(status) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+
+	return false
+}
+
+// isNetworkError reports whether the request failed at the network level (timeout, connection reset or closed).
+// http.Client reports all transport failures as *url.Error, including permanent ones, such as unsupported scheme,
+// invalid TLS certificate or refused token refresh, which are not network errors.
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	var (
+		retrieveErr  *oauth2.RetrieveError
+		authorityErr x509.UnknownAuthorityError
+		certErr      x509.CertificateInvalidError
+		hostnameErr  x509.HostnameError
+	)
+
+	if errors.As(urlErr.Err, &retrieveErr) ||
+		errors.As(urlErr.Err, &authorityErr) ||
+		errors.As(urlErr.Err, &certErr) ||
+		errors.As(urlErr.Err, &hostnameErr) {
+		return false
+	}
+
+	if errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF) || errors.Is(urlErr.Err, syscall.ECONNRESET) {
+		return true
+	}
+
+	// *url.Error implements net.Error itself, so check the underlying error only
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr)
+}
+
+// isRetryableStatus reports whether a request failed with the given HTTP status code may succeed if retried.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses Retry-After header value, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// sleep pauses the current goroutine for at least the duration d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,246 @@
+package patreon
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(writer, "<html>Service Unavailable</html>")
+		case 2:
+			writer.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(writer, throttledResp)
+		default:
+			fmt.Fprint(writer, currentUserResp)
+		}
+	})
+
+	resp, err := client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, "3232132131", resp.Data.ID)
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Drop the connection without response
+			conn, _, err := writer.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+
+		fmt.Fprint(writer, currentUserResp)
+	})
+
+	_, err := client.FetchUser()
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+}
+
+type errorTransport struct {
+	err      error
+	attempts int
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	return nil, t.err
+}
+
+func TestRetryPermanentTransportErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"unsupported", errors.New("unsupported protocol scheme")},
+		{"token refresh", &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusUnauthorized}}},
+		{"certificate", x509.UnknownAuthorityError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &errorTransport{err: tt.err}
+			client := NewClient(&http.Client{Transport: transport}, WithRetryPolicy(testRetryPolicy))
+
+			_, err := client.FetchUser()
+			require.Error(t, err)
+			require.Equal(t, 1, transport.attempts)
+		})
+	}
+
+	// Network level errors are retried
+	transport := &errorTransport{err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+	client := NewClient(&http.Client{Transport: transport}, WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Equal(t, testRetryPolicy.MaxAttempts, transport.attempts)
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		writer.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(writer, throttledResp)
+	})
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Equal(t, 3, attempts)
+
+	require.True(t, errors.Is(err, ErrRateLimited))
+
+	errResp := ErrorResponse{}
+	require.True(t, errors.As(err, &errResp))
+	require.True(t, errResp.Temporary())
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		writer.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(writer, errorResp)
+	})
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(writer, "{}")
+	})
+
+	_, err := client.FetchUser()
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryCanceledWhileWaiting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute}))
+
+	mux.HandleFunc("/oauth2/api/current_user", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadGateway)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.FetchUserContext(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	require.Equal(t, time.Second, policy.delay(1, 0))
+	require.Equal(t, 2*time.Second, policy.delay(2, 0))
+	require.Equal(t, 4*time.Second, policy.delay(3, 0))
+	require.Equal(t, 5*time.Second, policy.delay(4, 0))
+	require.Equal(t, 5*time.Second, policy.delay(100, 0))
+
+	// Retry-After takes precedence when it's longer
+	require.Equal(t, 10*time.Second, policy.delay(1, 10*time.Second))
+
+	policy.IgnoreRetryAfter = true
+	require.Equal(t, time.Second, policy.delay(1, 10*time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := policy.delay(1, 0)
+		require.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond, d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2017, 6, 20, 23, 21, 34, 0, time.UTC)
+
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("-1", now))
+	require.Equal(t, time.Minute, parseRetryAfter("Tue, 20 Jun 2017 23:22:34 GMT", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("Tue, 20 Jun 2017 23:20:34 GMT", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestErrorTemporary(t *testing.T) {
+	require.True(t, Error{Status: "503"}.Temporary())
+	require.True(t, Error{CodeName: "RequestThrottled"}.Temporary())
+	require.False(t, Error{Status: "401"}.Temporary())
+	require.False(t, ErrorResponse{}.Temporary())
+	require.Equal(t, 2*time.Second, ErrorResponse{Errors: []Error{{RetryAfterSeconds: 1}, {RetryAfterSeconds: 2}}}.retryAfter())
+}
+
+const throttledResp = `
+{
+    "errors": [
+        {
+            "code": null,
+            "code_name": "RequestThrottled",
+            "detail": "You have made too many attempts. Please try again later.",
+            "id": "8c5a3b3f-4e7f-4a49-a9c2-3cd5e1a57a4a",
+            "retry_after_seconds": 0,
+            "status": "429",
+            "title": "Request was throttled."
+        }
+    ]
+}
+`
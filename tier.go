@@ -0,0 +1,30 @@
+package patreon
+
+// Tier represents a membership level of a campaign (API v2), which supersedes API v1 reward.
+// Valid relationships: benefits, campaign, tier_image.
+type Tier struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		AmountCents      int      `json:"amount_cents"`
+		CreatedAt        NullTime `json:"created_at"`
+		Description      string   `json:"description"`
+		DiscordRoleIDs   []string `json:"discord_role_ids"`
+		EditedAt         NullTime `json:"edited_at"`
+		ImageURL         string   `json:"image_url"`
+		PatronCount      int      `json:"patron_count"`
+		PostCount        int      `json:"post_count"`
+		Published        bool     `json:"published"`
+		PublishedAt      NullTime `json:"published_at"`
+		Remaining        *int     `json:"remaining"`
+		RequiresShipping bool     `json:"requires_shipping"`
+		Title            string   `json:"title"`
+		UnpublishedAt    NullTime `json:"unpublished_at"`
+		URL              string   `json:"url"`
+		UserLimit        *int     `json:"user_limit"`
+	} `json:"attributes"`
+	Relationships struct {
+		Campaign *CampaignRelationship `json:"campaign,omitempty"`
+		Benefits *BenefitsRelationship `json:"benefits,omitempty"`
+	} `json:"relationships"`
+}
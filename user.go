@@ -1,10 +1,16 @@
 package patreon
 
-// UserDefaultRelations specifies default includes for User.
-const UserDefaultRelations = "campaign,pledges"
+const (
+	// UserDefaultRelations specifies default includes for User.
+	UserDefaultRelations = "campaign,pledges"
+
+	// IdentityDefaultRelations specifies default includes for User fetched with API v2 identity endpoint.
+	IdentityDefaultRelations = "memberships,campaign"
+)
 
 // User represents a Patreon's user.
 // Valid relationships: pledges, cards, follows, campaign, presence, session, locations, current_user_follow, pledge_to_current_user.
+// API v2 relationships: memberships, campaign.
 type User struct {
 	Type       string `json:"type"`
 	ID         string `json:"id"`
@@ -29,13 +35,27 @@ type User struct {
 		IsNuked         bool     `json:"is_nuked"`
 		Created         NullTime `json:"created"`
 		URL             string   `json:"url"`
-		DiscordId	string 	 `json:"discord_id"`
+		DiscordId       string   `json:"discord_id"`
+		// API v2 properties
+		CanSeeNSFW        bool                         `json:"can_see_nsfw"`
+		HidePledges       bool                         `json:"hide_pledges"`
+		LikeCount         int                          `json:"like_count"`
+		SocialConnections map[string]*SocialConnection `json:"social_connections"`
 	} `json:"attributes"`
 	Relationships struct {
-		Pledges *PledgesRelationship `json:"pledges,omitempty"`
+		Pledges     *PledgesRelationship     `json:"pledges,omitempty"`
+		Memberships *MembershipsRelationship `json:"memberships,omitempty"`
+		Campaign    *CampaignRelationship    `json:"campaign,omitempty"`
 	} `json:"relationships"`
 }
 
+// SocialConnection represents user's account on another platform.
+type SocialConnection struct {
+	UserID string   `json:"user_id"`
+	URL    string   `json:"url"`
+	Scopes []string `json:"scopes"`
+}
+
 // UserResponse wraps Patreon's fetch user API response
 type UserResponse struct {
 	Data     User     `json:"data"`
@@ -43,4 +63,6 @@ type UserResponse struct {
 	Links    struct {
 		Self string `json:"self"`
 	} `json:"links"`
+
+	documentSource
 }
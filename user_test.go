@@ -52,6 +52,57 @@ func TestFetchUser(t *testing.T) {
 	require.Equal(t, "pledge", pledges.Data[0].Type)
 }
 
+func TestFetchIdentity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/identity", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "memberships,campaign", request.URL.Query().Get("include"))
+		require.Equal(t, "full_name,like_count,social_connections", request.URL.Query().Get("fields[user]"))
+		fmt.Fprint(writer, identityResp)
+	})
+
+	resp, err := client.FetchIdentity(
+		WithIncludes(IdentityDefaultRelations),
+		WithFields("user", "full_name", "like_count", "social_connections"))
+	require.NoError(t, err)
+
+	require.Equal(t, "user", resp.Data.Type)
+	require.Equal(t, "3232132131", resp.Data.ID)
+	require.Equal(t, "Max", resp.Data.Attributes.FullName)
+	require.Equal(t, 12, resp.Data.Attributes.LikeCount)
+	require.Nil(t, resp.Data.Attributes.SocialConnections["youtube"])
+	require.Equal(t, "123456", resp.Data.Attributes.SocialConnections["discord"].UserID)
+
+	memberships := resp.Data.Relationships.Memberships
+	require.NotNil(t, memberships)
+	require.Len(t, memberships.Data, 1)
+	require.Equal(t, "c4b6e8a0-0d77-4a43-9a5b-1bb2b2c1e1c9", memberships.Data[0].ID)
+
+	campaign := resp.Data.Relationships.Campaign
+	require.NotNil(t, campaign)
+	require.Equal(t, "278915", campaign.Data.ID)
+
+	// Includes
+
+	require.Len(t, resp.Included.Items, 2)
+
+	member, ok := resp.Included.Items[0].(*Member)
+	require.True(t, ok)
+	require.Equal(t, "member", member.Type)
+	require.Equal(t, PatronStatusActive, member.Attributes.PatronStatus)
+	require.Equal(t, 500, member.Attributes.CurrentlyEntitledAmountCents)
+	require.Equal(t, 1500, member.Attributes.LifetimeSupportCents)
+	require.Equal(t, "Paid", member.Attributes.LastChargeStatus)
+	require.True(t, member.Attributes.PledgeRelationshipStart.Valid)
+	require.False(t, member.Attributes.NextChargeDate.Valid)
+
+	c, ok := resp.Included.Items[1].(*Campaign)
+	require.True(t, ok)
+	require.Equal(t, "podsync", c.Attributes.Vanity)
+	require.True(t, c.Attributes.HasRSS)
+}
+
 const currentUserResp = `
 {
     "data": {
@@ -106,3 +157,68 @@ const currentUserResp = `
     }
 }
 `
+
+const identityResp = `
+{
+    "data": {
+        "attributes": {
+            "full_name": "Max",
+            "like_count": 12,
+            "social_connections": {
+                "discord": {
+                    "url": null,
+                    "user_id": "123456"
+                },
+                "youtube": null
+            }
+        },
+        "id": "3232132131",
+        "relationships": {
+            "campaign": {
+                "data": {
+                    "id": "278915",
+                    "type": "campaign"
+                },
+                "links": {
+                    "related": "https://www.patreon.com/api/oauth2/v2/campaigns/278915"
+                }
+            },
+            "memberships": {
+                "data": [
+                    {
+                        "id": "c4b6e8a0-0d77-4a43-9a5b-1bb2b2c1e1c9",
+                        "type": "member"
+                    }
+                ]
+            }
+        },
+        "type": "user"
+    },
+    "included": [
+        {
+            "attributes": {
+                "currently_entitled_amount_cents": 500,
+                "last_charge_date": "2017-06-01T10:00:00.000+00:00",
+                "last_charge_status": "Paid",
+                "lifetime_support_cents": 1500,
+                "next_charge_date": null,
+                "patron_status": "active_patron",
+                "pledge_relationship_start": "2017-03-20T23:21:34.514822+00:00"
+            },
+            "id": "c4b6e8a0-0d77-4a43-9a5b-1bb2b2c1e1c9",
+            "type": "member"
+        },
+        {
+            "attributes": {
+                "has_rss": true,
+                "vanity": "podsync"
+            },
+            "id": "278915",
+            "type": "campaign"
+        }
+    ],
+    "links": {
+        "self": "https://www.patreon.com/api/oauth2/v2/user/3232132131"
+    }
+}
+`
@@ -4,6 +4,7 @@ import (
 	"crypto/hmac"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 )
 
 const (
@@ -15,6 +16,33 @@ const (
 
 	// EventDeletePledge specifies a delete pledge event
 	EventDeletePledge = "pledges:delete"
+
+	// EventCreateMember specifies a create member event (API v2)
+	EventCreateMember = "members:create"
+
+	// EventUpdateMember specifies an update member event (API v2)
+	EventUpdateMember = "members:update"
+
+	// EventDeleteMember specifies a delete member event (API v2)
+	EventDeleteMember = "members:delete"
+
+	// EventCreateMemberPledge specifies a create member's pledge event (API v2)
+	EventCreateMemberPledge = "members:pledge:create"
+
+	// EventUpdateMemberPledge specifies an update member's pledge event (API v2)
+	EventUpdateMemberPledge = "members:pledge:update"
+
+	// EventDeleteMemberPledge specifies a delete member's pledge event (API v2)
+	EventDeleteMemberPledge = "members:pledge:delete"
+
+	// EventPublishPost specifies a publish post event (API v2)
+	EventPublishPost = "posts:publish"
+
+	// EventUpdatePost specifies an update post event (API v2)
+	EventUpdatePost = "posts:update"
+
+	// EventDeletePost specifies a delete post event (API v2)
+	EventDeletePost = "posts:delete"
 )
 
 const (
@@ -25,19 +53,155 @@ const (
 	HeaderSignature = "X-Patreon-Signature"
 )
 
+// Webhook represents a webhook registered with API v2, which fires on the given triggers.
+// Valid relationships: client, campaign.
+type Webhook struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		LastAttemptedAt           NullTime `json:"last_attempted_at"`
+		NumConsecutiveTimesFailed int      `json:"num_consecutive_times_failed"`
+		Paused                    bool     `json:"paused"`
+		Secret                    string   `json:"secret"`
+		Triggers                  []string `json:"triggers"`
+		URI                       string   `json:"uri"`
+	} `json:"attributes"`
+	Relationships struct {
+		Campaign *CampaignRelationship `json:"campaign,omitempty"`
+	} `json:"relationships"`
+}
+
+// WebhookParams specifies webhook attributes to set on create or update.
+type WebhookParams struct {
+	URI      string   `json:"uri,omitempty"`
+	Triggers []string `json:"triggers,omitempty"`
+	Paused   *bool    `json:"paused,omitempty"`
+}
+
+// WebhookResponse wraps Patreon's create, update and fetch webhook API response
+type WebhookResponse struct {
+	Data     Webhook  `json:"data"`
+	Included Includes `json:"included"`
+
+	documentSource
+}
+
+// WebhooksResponse wraps Patreon's list webhooks API response
+type WebhooksResponse struct {
+	Data     []Webhook `json:"data"`
+	Included Includes  `json:"included"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Meta struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"meta"`
+
+	documentSource
+}
+
+// webhookDocument is a JSON:API document sent to create or update a webhook.
+type webhookDocument struct {
+	Data struct {
+		Type          string                `json:"type"`
+		ID            string                `json:"id,omitempty"`
+		Attributes    WebhookParams         `json:"attributes"`
+		Relationships *webhookRelationships `json:"relationships,omitempty"`
+	} `json:"data"`
+}
+
+type webhookRelationships struct {
+	Campaign struct {
+		Data Data `json:"data"`
+	} `json:"campaign"`
+}
+
+// WebhookPledge represents a payload of pledges:* webhook events.
 type WebhookPledge struct {
-	Data Pledge `json:"data"`
+	Data     Pledge   `json:"data"`
+	Included Includes `json:"included"`
+}
+
+// WebhookMember represents a payload of members:* webhook events.
+// User, Tiers and Campaign are resolved from Included items referenced by the member's relationships.
+type WebhookMember struct {
+	Data     Member   `json:"data"`
+	Included Includes `json:"included"`
+
+	User     *User     `json:"-"`
+	Tiers    []*Tier   `json:"-"`
+	Campaign *Campaign `json:"-"`
+}
+
+// UnmarshalJSON decodes the payload and resolves member's relationships.
+func (m *WebhookMember) UnmarshalJSON(b []byte) error {
+	type payload WebhookMember
+	if err := json.Unmarshal(b, (*payload)(m)); err != nil {
+		return err
+	}
+
+	rel := m.Data.Relationships
+	if rel.User != nil {
+		m.User = m.Included.User(rel.User.Data.ID)
+	}
+
+	if rel.Campaign != nil {
+		m.Campaign = m.Included.Campaign(rel.Campaign.Data.ID)
+	}
+
+	m.Tiers = nil
+	if rel.CurrentlyEntitledTiers != nil {
+		for _, data := range rel.CurrentlyEntitledTiers.Data {
+			if tier := m.Included.Tier(data.ID); tier != nil {
+				m.Tiers = append(m.Tiers, tier)
+			}
+		}
+	}
+
+	return nil
 }
 
-// VerifySignature verifies the sender of the message
+// WebhookPost represents a payload of posts:* webhook events.
+// User (post author) and Campaign are resolved from Included items referenced by the post's relationships.
+type WebhookPost struct {
+	Data     Post     `json:"data"`
+	Included Includes `json:"included"`
+
+	User     *User     `json:"-"`
+	Campaign *Campaign `json:"-"`
+}
+
+// UnmarshalJSON decodes the payload and resolves post's relationships.
+func (p *WebhookPost) UnmarshalJSON(b []byte) error {
+	type payload WebhookPost
+	if err := json.Unmarshal(b, (*payload)(p)); err != nil {
+		return err
+	}
+
+	rel := p.Data.Relationships
+	if rel.User != nil {
+		p.User = p.Included.User(rel.User.Data.ID)
+	}
+
+	if rel.Campaign != nil {
+		p.Campaign = p.Included.Campaign(rel.Campaign.Data.ID)
+	}
+
+	return nil
+}
+
+// VerifySignature verifies the sender of the message.
+// Signatures are compared in constant time to avoid leaking timing information.
 func VerifySignature(message []byte, secret string, signature string) (bool, error) {
 	hash := hmac.New(md5.New, []byte(secret))
 	if _, err := hash.Write(message); err != nil {
 		return false, err
 	}
 
-	sum := hash.Sum(nil)
-	expectedSignature := hex.EncodeToString(sum)
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, nil
+	}
 
-	return expectedSignature == signature, nil
+	return hmac.Equal(hash.Sum(nil), actual), nil
 }
@@ -0,0 +1,166 @@
+package patreon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+)
+
+// webhookMaxBodySize limits the size of webhook messages accepted by WebhookHandler.
+const webhookMaxBodySize = 1 << 20
+
+// WebhookHandler is an http.Handler which receives Patreon webhooks, verifies their signatures
+// and dispatches decoded payloads to the callbacks depending on the event type.
+//
+// Requests without signature are rejected with 401, requests with invalid signature with 403
+// and messages larger than 1MB with 413.
+// Events without a callback are acknowledged and ignored. If a callback returns an error,
+// the handler responds with 500, so Patreon will retry the delivery later.
+// Callback errors are logged and never sent back to the caller.
+type WebhookHandler struct {
+	// Secret is the webhook secret used to verify messages.
+	Secret string
+
+	// ErrorLog specifies an optional logger for errors returned by callbacks and malformed messages.
+	// If nil, errors are not logged.
+	ErrorLog *log.Logger
+
+	OnPledgeCreate func(ctx context.Context, pledge *WebhookPledge) error
+	OnPledgeUpdate func(ctx context.Context, pledge *WebhookPledge) error
+	OnPledgeDelete func(ctx context.Context, pledge *WebhookPledge) error
+
+	OnMemberCreate       func(ctx context.Context, member *WebhookMember) error
+	OnMemberUpdate       func(ctx context.Context, member *WebhookMember) error
+	OnMemberDelete       func(ctx context.Context, member *WebhookMember) error
+	OnMemberPledgeCreate func(ctx context.Context, member *WebhookMember) error
+	OnMemberPledgeUpdate func(ctx context.Context, member *WebhookMember) error
+	OnMemberPledgeDelete func(ctx context.Context, member *WebhookMember) error
+
+	OnPostPublish func(ctx context.Context, post *WebhookPost) error
+	OnPostUpdate  func(ctx context.Context, post *WebhookPost) error
+	OnPostDelete  func(ctx context.Context, post *WebhookPost) error
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBodySize+1))
+	if err != nil {
+		h.logf("patreon: failed to read webhook message: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if len(body) > webhookMaxBodySize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signature := r.Header.Get(HeaderSignature)
+	if signature == "" {
+		http.Error(w, "missing signature", http.StatusUnauthorized)
+		return
+	}
+
+	if ok, err := VerifySignature(body, h.Secret, signature); err != nil || !ok {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	event := r.Header.Get(HeaderEventType)
+	status, err := h.dispatch(r.Context(), event, body)
+	if err != nil {
+		// Don't expose internal errors to the caller
+		h.logf("patreon: failed to handle webhook event %s: %v", event, err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.WriteHeader(status)
+}
+
+func (h *WebhookHandler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	}
+}
+
+// dispatch decodes the message and calls the callback for the event.
+// It returns HTTP status code to respond with.
+func (h *WebhookHandler) dispatch(ctx context.Context, event string, body []byte) (int, error) {
+	switch event {
+	case EventCreatePledge:
+		return handlePledge(ctx, body, h.OnPledgeCreate)
+	case EventUpdatePledge:
+		return handlePledge(ctx, body, h.OnPledgeUpdate)
+	case EventDeletePledge:
+		return handlePledge(ctx, body, h.OnPledgeDelete)
+	case EventCreateMember:
+		return handleMember(ctx, body, h.OnMemberCreate)
+	case EventUpdateMember:
+		return handleMember(ctx, body, h.OnMemberUpdate)
+	case EventDeleteMember:
+		return handleMember(ctx, body, h.OnMemberDelete)
+	case EventCreateMemberPledge:
+		return handleMember(ctx, body, h.OnMemberPledgeCreate)
+	case EventUpdateMemberPledge:
+		return handleMember(ctx, body, h.OnMemberPledgeUpdate)
+	case EventDeleteMemberPledge:
+		return handleMember(ctx, body, h.OnMemberPledgeDelete)
+	case EventPublishPost:
+		return handlePost(ctx, body, h.OnPostPublish)
+	case EventUpdatePost:
+		return handlePost(ctx, body, h.OnPostUpdate)
+	case EventDeletePost:
+		return handlePost(ctx, body, h.OnPostDelete)
+	}
+
+	return http.StatusOK, nil
+}
+
+func handlePledge(ctx context.Context, body []byte, fn func(context.Context, *WebhookPledge) error) (int, error) {
+	if fn == nil {
+		return http.StatusOK, nil
+	}
+
+	pledge := &WebhookPledge{}
+	return handle(body, pledge, func() error { return fn(ctx, pledge) })
+}
+
+func handleMember(ctx context.Context, body []byte, fn func(context.Context, *WebhookMember) error) (int, error) {
+	if fn == nil {
+		return http.StatusOK, nil
+	}
+
+	member := &WebhookMember{}
+	return handle(body, member, func() error { return fn(ctx, member) })
+}
+
+func handlePost(ctx context.Context, body []byte, fn func(context.Context, *WebhookPost) error) (int, error) {
+	if fn == nil {
+		return http.StatusOK, nil
+	}
+
+	post := &WebhookPost{}
+	return handle(body, post, func() error { return fn(ctx, post) })
+}
+
+// handle decodes the message into payload and invokes the callback.
+func handle(body []byte, payload interface{}, call func() error) (int, error) {
+	if err := json.Unmarshal(body, payload); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := call(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
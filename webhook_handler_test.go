@@ -0,0 +1,241 @@
+package patreon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const pledgeCreateSignature = "d339d4fa026a468919188cde6128b507"
+
+func sendWebhook(handler http.Handler, event string, signature string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if event != "" {
+		req.Header.Set(HeaderEventType, event)
+	}
+
+	if signature != "" {
+		req.Header.Set(HeaderSignature, signature)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func sign(body string) string {
+	hash := hmac.New(md5.New, []byte(webhookSecret))
+	hash.Write([]byte(body))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func TestWebhookHandlerPledgeCreate(t *testing.T) {
+	var received *WebhookPledge
+
+	handler := &WebhookHandler{
+		Secret: webhookSecret,
+		OnPledgeCreate: func(ctx context.Context, pledge *WebhookPledge) error {
+			received = pledge
+			return nil
+		},
+		OnPledgeDelete: func(ctx context.Context, pledge *WebhookPledge) error {
+			t.Fatal("unexpected delete event")
+			return nil
+		},
+	}
+
+	rec := sendWebhook(handler, EventCreatePledge, pledgeCreateSignature, pledgeCreateMessage)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.NotNil(t, received)
+	require.Equal(t, "1", received.Data.ID)
+	require.Equal(t, 150, received.Data.Attributes.AmountCents)
+	require.Len(t, received.Included.Items, 11)
+
+	user, ok := received.Included.Items[0].(*User)
+	require.True(t, ok)
+	require.Equal(t, "4221587", user.ID)
+}
+
+func TestWebhookHandlerSignature(t *testing.T) {
+	handler := &WebhookHandler{
+		Secret: webhookSecret,
+		OnPledgeCreate: func(ctx context.Context, pledge *WebhookPledge) error {
+			t.Fatal("callback must not be called")
+			return nil
+		},
+	}
+
+	rec := sendWebhook(handler, EventCreatePledge, "", pledgeCreateMessage)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = sendWebhook(handler, EventCreatePledge, "d339d4fa026a468919188cde6128b508", pledgeCreateMessage)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestWebhookHandlerCallbackError(t *testing.T) {
+	logs := &bytes.Buffer{}
+	handler := &WebhookHandler{
+		Secret:   webhookSecret,
+		ErrorLog: log.New(logs, "", 0),
+		OnPledgeUpdate: func(ctx context.Context, pledge *WebhookPledge) error {
+			return errors.New("database is down")
+		},
+	}
+
+	rec := sendWebhook(handler, EventUpdatePledge, pledgeCreateSignature, pledgeCreateMessage)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.NotContains(t, rec.Body.String(), "database is down")
+	require.Contains(t, logs.String(), "database is down")
+
+	// Errors are discarded without ErrorLog
+	std := &bytes.Buffer{}
+	log.SetOutput(std)
+	defer log.SetOutput(os.Stderr)
+
+	handler.ErrorLog = nil
+	rec = sendWebhook(handler, EventUpdatePledge, pledgeCreateSignature, pledgeCreateMessage)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Empty(t, std.String())
+}
+
+func TestWebhookHandlerTooLarge(t *testing.T) {
+	handler := &WebhookHandler{Secret: webhookSecret}
+
+	body := strings.Repeat(" ", webhookMaxBodySize+1)
+	rec := sendWebhook(handler, EventCreatePledge, sign(body), body)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	require.Equal(t, http.StatusText(http.StatusRequestEntityTooLarge)+"\n", rec.Body.String())
+}
+
+func TestWebhookHandlerIgnoredEvents(t *testing.T) {
+	handler := &WebhookHandler{Secret: webhookSecret}
+
+	rec := sendWebhook(handler, EventDeletePledge, pledgeCreateSignature, pledgeCreateMessage)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = sendWebhook(handler, "unknown:event", pledgeCreateSignature, pledgeCreateMessage)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestWebhookHandlerMethod(t *testing.T) {
+	handler := &WebhookHandler{Secret: webhookSecret}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestWebhookHandlerMemberPledgeCreate(t *testing.T) {
+	var received *WebhookMember
+
+	handler := &WebhookHandler{
+		Secret: webhookSecret,
+		OnMemberPledgeCreate: func(ctx context.Context, member *WebhookMember) error {
+			received = member
+			return nil
+		},
+		OnMemberCreate: func(ctx context.Context, member *WebhookMember) error {
+			t.Fatal("unexpected members:create event")
+			return nil
+		},
+	}
+
+	rec := sendWebhook(handler, EventCreateMemberPledge, sign(memberPledgeMessage), memberPledgeMessage)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.NotNil(t, received)
+	require.Equal(t, "03ca69c3-ebea-4b9a-8fac-e4a837873254", received.Data.ID)
+	require.Equal(t, PatronStatusActive, received.Data.Attributes.PatronStatus)
+	require.Len(t, received.Included.Items, 4)
+
+	require.NotNil(t, received.User)
+	require.Equal(t, "2822191", received.User.ID)
+	require.Equal(t, "Max", received.User.Attributes.FullName)
+
+	require.NotNil(t, received.Campaign)
+	require.Equal(t, "278915", received.Campaign.ID)
+
+	require.Len(t, received.Tiers, 2)
+	require.Equal(t, "Supporter", received.Tiers[0].Attributes.Title)
+	require.Equal(t, "Producer", received.Tiers[1].Attributes.Title)
+}
+
+func TestWebhookHandlerPostPublish(t *testing.T) {
+	var received *WebhookPost
+
+	handler := &WebhookHandler{
+		Secret: webhookSecret,
+		OnPostPublish: func(ctx context.Context, post *WebhookPost) error {
+			received = post
+			return nil
+		},
+	}
+
+	rec := sendWebhook(handler, EventPublishPost, sign(postPublishMessage), postPublishMessage)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.NotNil(t, received)
+	require.Equal(t, "Episode 1", received.Data.Attributes.Title)
+	require.NotNil(t, received.Campaign)
+	require.Equal(t, "278915", received.Campaign.ID)
+	require.NotNil(t, received.User)
+	require.Equal(t, "2822191", received.User.ID)
+}
+
+func TestWebhookMemberMissingIncludes(t *testing.T) {
+	member := &WebhookMember{}
+	err := json.Unmarshal([]byte(`{"data": {"id": "1", "type": "member", "relationships": {"user": {"data": {"id": "2", "type": "user"}}}}}`), member)
+	require.NoError(t, err)
+	require.Nil(t, member.User)
+	require.Nil(t, member.Campaign)
+	require.Empty(t, member.Tiers)
+}
+
+const memberPledgeMessage = `{
+    "data": {
+        "attributes": {"currently_entitled_amount_cents": 600, "patron_status": "active_patron"},
+        "id": "03ca69c3-ebea-4b9a-8fac-e4a837873254",
+        "relationships": {
+            "address": {"data": null},
+            "campaign": {"data": {"id": "278915", "type": "campaign"}},
+            "currently_entitled_tiers": {"data": [{"id": "1", "type": "tier"}, {"id": "2", "type": "tier"}]},
+            "user": {"data": {"id": "2822191", "type": "user"}}
+        },
+        "type": "member"
+    },
+    "included": [
+        {"attributes": {"vanity": "podsync"}, "id": "278915", "type": "campaign"},
+        {"attributes": {"full_name": "Max"}, "id": "2822191", "type": "user"},
+        {"attributes": {"amount_cents": 100, "title": "Supporter"}, "id": "1", "type": "tier"},
+        {"attributes": {"amount_cents": 500, "title": "Producer"}, "id": "2", "type": "tier"}
+    ]
+}`
+
+const postPublishMessage = `{
+    "data": {
+        "attributes": {"title": "Episode 1", "is_paid": true},
+        "id": "1001",
+        "relationships": {
+            "campaign": {"data": {"id": "278915", "type": "campaign"}},
+            "user": {"data": {"id": "2822191", "type": "user"}}
+        },
+        "type": "post"
+    },
+    "included": [
+        {"attributes": {}, "id": "278915", "type": "campaign"},
+        {"attributes": {}, "id": "2822191", "type": "user"}
+    ]
+}`
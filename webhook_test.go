@@ -1,6 +1,10 @@
 package patreon
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -20,3 +24,146 @@ func TestVerifySignature(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, result)
 }
+
+func TestCreateWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/webhooks", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, http.MethodPost, request.Method)
+		require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"data": {
+				"type": "webhook",
+				"attributes": {
+					"uri": "https://example.com/patreon",
+					"triggers": ["members:pledge:create", "members:pledge:delete"]
+				},
+				"relationships": {
+					"campaign": {"data": {"type": "campaign", "id": "278915"}}
+				}
+			}
+		}`, string(body))
+
+		writer.WriteHeader(http.StatusCreated)
+		fmt.Fprint(writer, webhookResp)
+	})
+
+	resp, err := client.CreateWebhook("278915", WebhookParams{
+		URI:      "https://example.com/patreon",
+		Triggers: []string{"members:pledge:create", "members:pledge:delete"},
+	})
+	require.NoError(t, err)
+
+	webhook := resp.Data
+	require.Equal(t, "webhook", webhook.Type)
+	require.Equal(t, "51", webhook.ID)
+	require.Equal(t, "https://example.com/patreon", webhook.Attributes.URI)
+	require.Equal(t, []string{"members:pledge:create", "members:pledge:delete"}, webhook.Attributes.Triggers)
+	require.Equal(t, webhookSecret, webhook.Attributes.Secret)
+	require.False(t, webhook.Attributes.Paused)
+	require.False(t, webhook.Attributes.LastAttemptedAt.Valid)
+	require.Equal(t, 0, webhook.Attributes.NumConsecutiveTimesFailed)
+	require.NotNil(t, webhook.Relationships.Campaign)
+	require.Equal(t, "278915", webhook.Relationships.Campaign.Data.ID)
+}
+
+func TestListWebhooks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/webhooks", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, http.MethodGet, request.Method)
+		require.Equal(t, "uri,paused,secret", request.URL.Query().Get("fields[webhook]"))
+		fmt.Fprintf(writer, `{"data": [%s], "meta": {"pagination": {"total": 1}}}`, webhookData)
+	})
+
+	resp, err := client.ListWebhooks(WithFields("webhook", "uri", "paused", "secret"))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, "51", resp.Data[0].ID)
+	require.Equal(t, 1, resp.Meta.Pagination.Total)
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/webhooks/51", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, http.MethodPatch, request.Method)
+
+		doc := struct {
+			Data struct {
+				ID         string                 `json:"id"`
+				Type       string                 `json:"type"`
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"data"`
+		}{}
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&doc))
+		require.Equal(t, "51", doc.Data.ID)
+		require.Equal(t, "webhook", doc.Data.Type)
+		require.Equal(t, map[string]interface{}{"paused": false}, doc.Data.Attributes)
+
+		fmt.Fprint(writer, webhookResp)
+	})
+
+	paused := false
+	resp, err := client.UpdateWebhook("51", WebhookParams{Paused: &paused})
+	require.NoError(t, err)
+	require.Equal(t, "51", resp.Data.ID)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/oauth2/v2/webhooks/51", func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, http.MethodDelete, request.Method)
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, client.DeleteWebhook("51"))
+}
+
+func TestDeleteWebhookIsNotRetried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+	attempts := 0
+	mux.HandleFunc("/api/oauth2/v2/webhooks/51", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(writer, "{}")
+	})
+
+	require.Error(t, client.DeleteWebhook("51"))
+	require.Equal(t, 1, attempts)
+}
+
+const webhookData = `
+{
+    "attributes": {
+        "last_attempted_at": null,
+        "num_consecutive_times_failed": 0,
+        "paused": false,
+        "secret": "VOOskLxZ_AVczRZaHVZSth4i5mCR8QAvWXlGkp75V7Yz1Zs5rvAfrdB0SXcItR-j",
+        "triggers": ["members:pledge:create", "members:pledge:delete"],
+        "uri": "https://example.com/patreon"
+    },
+    "id": "51",
+    "relationships": {
+        "campaign": {
+            "data": {"id": "278915", "type": "campaign"}
+        }
+    },
+    "type": "webhook"
+}
+`
+
+const webhookResp = `{"data": ` + webhookData + `}`